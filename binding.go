@@ -0,0 +1,160 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONPointer convert dot separated path of the field, e.g. items.0.quantity, to RFC 6901 JSON pointer /items/0/quantity
+func JSONPointer(path string) string {
+	if path == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, token := range strings.Split(path, ".") {
+		b.WriteString(pointerToken(token))
+	}
+	return b.String()
+}
+
+// pointerToken escaped reference token of RFC 6901 JSON pointer with its leading slash
+func pointerToken(token string) string {
+	return "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// BindingError error of binding the request, e.g. decoding its body or parsing its parameters, the mappings of
+// BindingMappings for empty bodies, unknown fields, numbers and times match only errors wrapped in BindingError
+type BindingError struct {
+	// Err error of the binding
+	Err error
+	// Body json body of the request, with Value it resolves the exact JSON pointer of unknown fields
+	Body []byte
+	// Value destination of the json body, e.g. pointer to the bound struct
+	Value interface{}
+	// Parameter name of the query, path or form parameter whose value failed, e.g. of strconv and time errors
+	Parameter string
+	// Location location of the parameter in the request
+	Location Location
+}
+
+// NewBindingError wrap error of binding the request in BindingError, it returns nil when err is nil
+func NewBindingError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &BindingError{Err: err}
+}
+
+func (e *BindingError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error of the binding
+func (e *BindingError) Unwrap() error {
+	return e.Err
+}
+
+// MatchBinding match errors wrapped in BindingError whose error matches match
+func MatchBinding(match func(err error) bool) func(err error) bool {
+	return func(err error) bool {
+		var bindingErr *BindingError
+		return errors.As(err, &bindingErr) && match(bindingErr.Err)
+	}
+}
+
+// BindingMappings mappings of the errors of decoding request body with encoding/json and parameters with strconv and time,
+// the json.SyntaxError and json.UnmarshalTypeError mappings are the ones of StandardMappings
+func BindingMappings() []Mapping {
+	return []Mapping{
+		jsonSyntaxMapping(),
+		jsonTypeMapping(),
+		{
+			Name: "json.UnknownField",
+			Match: MatchBinding(func(err error) bool {
+				_, ok := unknownField(err)
+				return ok
+			}),
+			Problem: func(err error) ProblemDetailErr {
+				field, _ := unknownField(err)
+				var bindingErr *BindingError
+				errors.As(err, &bindingErr)
+
+				// encoding/json reports the bare member name, so its pointer is known only with the body and its destination
+				fieldErr := FieldError{Location: LocationBody, Reason: fmt.Sprintf("member %q is unknown", field), Code: "unknown_field"}
+				if bindingErr.Body != nil && bindingErr.Value != nil {
+					fieldErr.Pointer, _ = unknownMemberAt(bindingErr.Body, reflect.TypeOf(bindingErr.Value), field)
+					fieldErr.Reason = "is unknown"
+				}
+				p := NewValidationProblem(fieldErr)
+				p.Detail = fmt.Sprintf("request body has unknown field %q", field)
+				return p
+			},
+		},
+		{
+			Name: "io.EOF",
+			Match: MatchBinding(func(err error) bool {
+				return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+			}),
+			Problem: func(err error) ProblemDetailErr {
+				return &ProblemDetail{Status: http.StatusBadRequest, Detail: "request body is empty or truncated"}
+			},
+		},
+		{
+			Name:  "strconv.NumError",
+			Match: MatchBinding(MatchAs[*strconv.NumError]()),
+			Problem: func(err error) ProblemDetailErr {
+				var numErr *strconv.NumError
+				errors.As(err, &numErr)
+				p := &ProblemDetail{Status: http.StatusBadRequest, Detail: fmt.Sprintf("value %q is invalid: %s", numErr.Num, numErr.Err)}
+				return attachParameterError(p, err, fmt.Sprintf("is invalid: %s", numErr.Err))
+			},
+		},
+		{
+			Name:  "time.ParseError",
+			Match: MatchBinding(MatchAs[*time.ParseError]()),
+			Problem: func(err error) ProblemDetailErr {
+				var parseErr *time.ParseError
+				errors.As(err, &parseErr)
+				p := &ProblemDetail{Status: http.StatusBadRequest, Detail: fmt.Sprintf("value %q is not a valid time of layout %q", parseErr.Value, parseErr.Layout)}
+				return attachParameterError(p, err, fmt.Sprintf("must be a time of layout %q", parseErr.Layout))
+			},
+		},
+	}
+}
+
+// attachParameterError attach field error of the parameter of the binding error to the problem, the problem is returned
+// as it is when the parameter is unknown
+func attachParameterError(p *ProblemDetail, err error, reason string) ProblemDetailErr {
+	var bindingErr *BindingError
+	if !errors.As(err, &bindingErr) || bindingErr.Parameter == "" {
+		return p
+	}
+	p.Detail = fmt.Sprintf("parameter %q %s", bindingErr.Parameter, reason)
+	return AttachFieldErrors(p, FieldError{
+		Parameter: bindingErr.Parameter,
+		Location:  bindingErr.Location,
+		Reason:    reason,
+		Code:      "invalid_value",
+	})
+}
+
+// unknownField field of the error of encoding/json decoder with DisallowUnknownFields in the error chain, the error
+// has no type of its own, so the whole message of an error of the chain must be the one of encoding/json
+func unknownField(err error) (string, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		quoted, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+		if !ok {
+			continue
+		}
+		if field, unquoteErr := strconv.Unquote(quoted); unquoteErr == nil {
+			return field, true
+		}
+	}
+	return "", false
+}
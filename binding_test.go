@@ -0,0 +1,87 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestJSONPointer(t *testing.T) {
+
+	assert.Equal(t, "/items/0/quantity", JSONPointer("items.0.quantity"))
+	assert.Equal(t, "/a~1b/m~0n", JSONPointer("a/b.m~n"))
+	assert.Equal(t, "", JSONPointer(""))
+}
+
+func TestResolver_Binding_Mappings(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodPost, "http://endpoint8/orders", nil)
+	resolver := NewResolver(WithMappings(StandardMappings()...), WithMappings(BindingMappings()...))
+
+	var order struct {
+		Customer struct {
+			Age int `json:"age"`
+		} `json:"customer"`
+	}
+	typeErr := json.Unmarshal([]byte(`{"customer":{"age":"ten"}}`), &order)
+
+	p := resolver.ToProblemDetails(req, typeErr)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, p.GetStatus())
+	assert.Equal(t, []FieldError{{Pointer: "/customer/age", Location: LocationBody, Reason: "must be int", Code: "invalid_type"}}, Extensions(p)[ErrorsExtension])
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(`{"note":"fast"}`)))
+	decoder.DisallowUnknownFields()
+	p = resolver.ToProblemDetails(req, NewBindingError(decoder.Decode(&order)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, p.GetStatus())
+	assert.Equal(t, []FieldError{{Location: LocationBody, Reason: `member "note" is unknown`, Code: "unknown_field"}}, Extensions(p)[ErrorsExtension])
+
+	body := []byte(`{"customer":{"age":10,"note":"fast"}}`)
+	decoder = json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	p = resolver.ToProblemDetails(req, &BindingError{Err: decoder.Decode(&order), Body: body, Value: &order})
+
+	assert.Equal(t, []FieldError{{Pointer: "/customer/note", Location: LocationBody, Reason: "is unknown", Code: "unknown_field"}}, Extensions(p)[ErrorsExtension])
+
+	p = resolver.ToProblemDetails(req, NewBindingError(json.NewDecoder(bytes.NewReader(nil)).Decode(&order)))
+	assert.Equal(t, http.StatusBadRequest, p.GetStatus())
+
+	_, numErr := strconv.Atoi("ten")
+	p = resolver.ToProblemDetails(req, fmt.Errorf("bind page: %w", NewBindingError(numErr)))
+
+	assert.Equal(t, http.StatusBadRequest, p.GetStatus())
+	assert.Equal(t, `value "ten" is invalid: invalid syntax`, p.GetDetails())
+
+	p = resolver.ToProblemDetails(req, &BindingError{Err: numErr, Parameter: "page", Location: LocationQuery})
+
+	assert.Equal(t, `parameter "page" is invalid: invalid syntax`, p.GetDetails())
+	assert.Equal(t, []FieldError{{Parameter: "page", Location: LocationQuery, Reason: "is invalid: invalid syntax", Code: "invalid_value"}}, Extensions(p)[ErrorsExtension])
+
+	_, parseErr := time.Parse(time.DateOnly, "yesterday")
+	p = resolver.ToProblemDetails(req, &BindingError{Err: parseErr, Parameter: "since", Location: LocationQuery})
+
+	assert.Equal(t, []FieldError{{Parameter: "since", Location: LocationQuery, Reason: `must be a time of layout "2006-01-02"`, Code: "invalid_value"}}, Extensions(p)[ErrorsExtension])
+}
+
+func TestResolver_Binding_Mappings_Server_Errors(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint8/reports", nil)
+	resolver := NewResolver(WithMappings(BindingMappings()...))
+
+	_, numErr := strconv.Atoi("ten")
+	_, parseErr := time.Parse(time.DateOnly, "yesterday")
+
+	assert.Equal(t, http.StatusInternalServerError, resolver.ToProblemDetails(req, numErr).GetStatus())
+	assert.Equal(t, http.StatusInternalServerError, resolver.ToProblemDetails(req, parseErr).GetStatus())
+	assert.Equal(t, http.StatusInternalServerError, resolver.ToProblemDetails(req, fmt.Errorf("read config: %w", io.EOF)).GetStatus())
+	assert.Equal(t, http.StatusInternalServerError, resolver.ToProblemDetails(req, NewBindingError(errors.New(`load: json: unknown field "note"`))).GetStatus())
+}
@@ -0,0 +1,90 @@
+package problem
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DebugHeader header of the debug token used by HMACVerifier by default
+	DebugHeader = "X-Debug-Token"
+	// CausesExtension extension member carrying messages of the cause chain of the error for verified debug requests
+	CausesExtension = "causes"
+)
+
+// DebugVerifier verify debug credential of the request, stack trace and cause chain of the error are included
+// in the response only for verified requests
+type DebugVerifier interface {
+	Verify(r *http.Request) bool
+}
+
+// DebugVerifierFunc function adapter of DebugVerifier
+type DebugVerifierFunc func(r *http.Request) bool
+
+func (f DebugVerifierFunc) Verify(r *http.Request) bool {
+	return f(r)
+}
+
+// HMACVerifier verify debug token of the header signed by SignDebugToken with the secret, tokens older than maxAge
+// are rejected. X-Debug-Token header is used when header is empty
+func HMACVerifier(header string, secret []byte, maxAge time.Duration) DebugVerifier {
+	if header == "" {
+		header = DebugHeader
+	}
+	return DebugVerifierFunc(func(r *http.Request) bool {
+		timestamp, signature, ok := strings.Cut(r.Header.Get(header), ".")
+		if !ok {
+			return false
+		}
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return false
+		}
+		if age := time.Since(time.Unix(unix, 0)); age > maxAge || age < -maxAge {
+			return false
+		}
+		expected, err := hex.DecodeString(signature)
+		if err != nil {
+			return false
+		}
+		return hmac.Equal(expected, debugSignature(secret, timestamp))
+	})
+}
+
+// SignDebugToken create debug token for HMACVerifier signed with the secret at the time
+func SignDebugToken(secret []byte, t time.Time) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	return timestamp + "." + hex.EncodeToString(debugSignature(secret, timestamp))
+}
+
+func debugSignature(secret []byte, timestamp string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	return mac.Sum(nil)
+}
+
+// causeChain messages of the error and its wrapped errors in unwrapping order
+func causeChain(err error) []string {
+	var causes []string
+	var walk func(err error)
+	walk = func(err error) {
+		for err != nil {
+			causes = append(causes, err.Error())
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, e := range joined.Unwrap() {
+					walk(e)
+				}
+				return
+			}
+			err = errors.Unwrap(err)
+		}
+	}
+	walk(err)
+	return causes
+}
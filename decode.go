@@ -0,0 +1,334 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PointerExtension extension member carrying RFC 6901 JSON pointer of the failing location of the request body
+	PointerExtension = "pointer"
+	// ExpectedTypeExtension extension member carrying the expected type of the failing location of the request body
+	ExpectedTypeExtension = "expectedType"
+	// OffsetExtension extension member carrying the byte offset of the failing location of the request body
+	OffsetExtension = "offset"
+)
+
+// DecodeOption configure DecodeJSON
+type DecodeOption func(*decodeOptions)
+
+type decodeOptions struct {
+	disallowUnknownFields bool
+	maxBytes              int64
+}
+
+// DisallowUnknownFields fail decoding when the body has a member without destination field
+func DisallowUnknownFields() DecodeOption {
+	return func(opts *decodeOptions) {
+		opts.disallowUnknownFields = true
+	}
+}
+
+// MaxBodyBytes fail decoding with 413 problem when the body is larger than n bytes
+func MaxBodyBytes(n int64) DecodeOption {
+	return func(opts *decodeOptions) {
+		opts.maxBytes = n
+	}
+}
+
+// DecodeJSON decode json body of the request into v with encoding/json, on failure it returns problem details error
+// with the JSON pointer, expected type and byte offset of the failing location which is resolved by ResolveProblemDetails
+func DecodeJSON(r *http.Request, v interface{}, opts ...DecodeOption) error {
+	var options decodeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var body io.Reader = r.Body
+	if r.Body == nil {
+		body = http.NoBody
+	}
+	if options.maxBytes > 0 {
+		body = http.MaxBytesReader(nil, io.NopCloser(body), options.maxBytes)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return (&ProblemDetail{
+				Status: http.StatusRequestEntityTooLarge,
+				Detail: fmt.Sprintf("request body is larger than %d bytes", maxBytesErr.Limit),
+			}).SetExtension("limit", maxBytesErr.Limit).(error)
+		}
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if options.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(v); err != nil {
+		return decodeProblem(data, reflect.TypeOf(v), err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		offset := decoder.InputOffset()
+		return bodyProblem(http.StatusBadRequest, "request body must have a single json value", "", "", offset)
+	}
+	return nil
+}
+
+func decodeProblem(data []byte, t reflect.Type, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return &ProblemDetail{Status: http.StatusBadRequest, Detail: "request body is empty"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return bodyProblem(http.StatusBadRequest, "request body has truncated json", jsonPointerAt(data, int64(len(data))), "", int64(len(data)))
+	case errors.As(err, &syntaxErr):
+		return bodyProblem(http.StatusBadRequest, fmt.Sprintf("request body has malformed json: %s", syntaxErr.Error()), jsonPointerAt(data, syntaxErr.Offset), "", syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		pointer := jsonPointerAt(data, typeErr.Offset)
+		detail := fmt.Sprintf("request body has json %s at %q which must be %s", typeErr.Value, pointer, typeErr.Type)
+		return bodyProblem(http.StatusUnprocessableEntity, detail, pointer, typeErr.Type.String(), typeErr.Offset)
+	}
+	if field, ok := unknownField(err); ok {
+		pointer, offset := unknownMemberAt(data, t, field)
+		p := &ProblemDetail{
+			Status: http.StatusUnprocessableEntity,
+			Detail: fmt.Sprintf("request body has unknown field %q", pointer),
+		}
+		p.SetExtension(PointerExtension, pointer)
+		p.SetExtension(OffsetExtension, offset)
+		return AttachFieldErrors(p, FieldError{Pointer: pointer, Location: LocationBody, Reason: "is unknown", Code: "unknown_field"}).(error)
+	}
+	return &ProblemDetail{Status: http.StatusBadRequest, Detail: err.Error()}
+}
+
+func bodyProblem(status int, detail string, pointer string, expectedType string, offset int64) error {
+	p := &ProblemDetail{Status: status, Detail: detail}
+	p.SetExtension(PointerExtension, pointer)
+	p.SetExtension(OffsetExtension, offset)
+
+	fieldErr := FieldError{Pointer: pointer, Location: LocationBody, Reason: "is malformed json", Code: "malformed"}
+	if expectedType != "" {
+		p.SetExtension(ExpectedTypeExtension, expectedType)
+		fieldErr.Reason, fieldErr.Code = "must be "+expectedType, "invalid_type"
+	}
+	return AttachFieldErrors(p, fieldErr).(error)
+}
+
+// pointerFrame container of the json document with its current member name or element index
+type pointerFrame struct {
+	array   bool
+	index   int
+	key     string
+	wantKey bool
+}
+
+// jsonPointerAt RFC 6901 JSON pointer of the value of the json document which is read until the offset
+func jsonPointerAt(data []byte, offset int64) string {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var stack []*pointerFrame
+
+	valueDone := func() {
+		if len(stack) == 0 {
+			return
+		}
+		if top := stack[len(stack)-1]; top.array {
+			top.index++
+		} else {
+			top.wantKey = true
+		}
+	}
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return framesPointer(stack)
+		}
+		reached := decoder.InputOffset() >= offset
+
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			if reached {
+				return framesPointer(stack)
+			}
+			valueDone()
+			continue
+		}
+		if len(stack) > 0 && stack[len(stack)-1].wantKey {
+			stack[len(stack)-1].key, _ = tok.(string)
+			stack[len(stack)-1].wantKey = false
+			continue
+		}
+		if reached {
+			return framesPointer(stack)
+		}
+		if delim, ok := tok.(json.Delim); ok {
+			stack = append(stack, &pointerFrame{array: delim == '[', wantKey: delim == '{'})
+			continue
+		}
+		valueDone()
+	}
+}
+
+// typeFrame container of the json document with the destination type of its members or elements, nil when they
+// aren't checked, e.g. of interface{} and json.Unmarshaler destinations
+type typeFrame struct {
+	pointerFrame
+	t reflect.Type
+}
+
+// unknownMemberAt JSON pointer and byte offset of the first member of the json document which has no destination
+// field in t, the member the unknown field error of encoding/json is about, the field is resolved at the root when
+// the member isn't found
+func unknownMemberAt(data []byte, t reflect.Type, field string) (string, int64) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var stack []*typeFrame
+
+	frames := func() []*pointerFrame {
+		pointerFrames := make([]*pointerFrame, len(stack))
+		for i, frame := range stack {
+			pointerFrames[i] = &frame.pointerFrame
+		}
+		return pointerFrames
+	}
+	valueType := func() reflect.Type {
+		if len(stack) == 0 {
+			return checkedType(t)
+		}
+		top := stack[len(stack)-1]
+		if top.t == nil {
+			return nil
+		}
+		if top.array {
+			return checkedType(top.t.Elem())
+		}
+		if top.t.Kind() == reflect.Map {
+			return checkedType(top.t.Elem())
+		}
+		fieldType, _ := jsonFieldType(top.t, top.key)
+		return checkedType(fieldType)
+	}
+	valueDone := func() {
+		if len(stack) == 0 {
+			return
+		}
+		if top := stack[len(stack)-1]; top.array {
+			top.index++
+		} else {
+			top.wantKey = true
+		}
+	}
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return JSONPointer(field), 0
+		}
+
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			valueDone()
+			continue
+		}
+		if len(stack) > 0 && stack[len(stack)-1].wantKey {
+			top := stack[len(stack)-1]
+			top.key, _ = tok.(string)
+			top.wantKey = false
+			if top.t != nil && top.t.Kind() == reflect.Struct {
+				if _, ok := jsonFieldType(top.t, top.key); !ok && top.key == field {
+					return framesPointer(frames()), decoder.InputOffset()
+				}
+			}
+			continue
+		}
+		if delim, ok := tok.(json.Delim); ok {
+			frameType := valueType()
+			if delim == '[' && frameType != nil && frameType.Kind() != reflect.Slice && frameType.Kind() != reflect.Array {
+				frameType = nil
+			}
+			if delim == '{' && frameType != nil && frameType.Kind() != reflect.Struct && frameType.Kind() != reflect.Map {
+				frameType = nil
+			}
+			stack = append(stack, &typeFrame{pointerFrame: pointerFrame{array: delim == '[', wantKey: delim == '{'}, t: frameType})
+			continue
+		}
+		valueDone()
+	}
+}
+
+// checkedType destination type whose members are checked, pointers are dereferenced and types which decode
+// themselves or accept any value aren't checked
+func checkedType(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Interface || reflect.PointerTo(t).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
+		return nil
+	}
+	return t
+}
+
+// jsonFieldType type of the field of the struct which encoding/json decodes the member into, the member name is
+// matched exactly first and then case-insensitively, fields of embedded structs are promoted
+func jsonFieldType(t reflect.Type, name string) (reflect.Type, bool) {
+	var folded reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		tagName, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && tagName == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if fieldType, ok := jsonFieldType(embedded, name); ok {
+					return fieldType, true
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if tagName == "" {
+			tagName = field.Name
+		}
+		if tagName == name {
+			return field.Type, true
+		}
+		if folded == nil && strings.EqualFold(tagName, name) {
+			folded = field.Type
+		}
+	}
+	return folded, folded != nil
+}
+
+func framesPointer(stack []*pointerFrame) string {
+	var b strings.Builder
+	for _, frame := range stack {
+		if frame.array {
+			b.WriteString("/" + strconv.Itoa(frame.index))
+			continue
+		}
+		b.WriteString(pointerToken(frame.key))
+	}
+	return b.String()
+}
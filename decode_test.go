@@ -0,0 +1,71 @@
+package problem
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type decodeOrder struct {
+	Customer struct {
+		Name string `json:"name"`
+	} `json:"customer"`
+	Items []struct {
+		Quantity int `json:"quantity"`
+	} `json:"items"`
+}
+
+func TestDecodeJSON_Type_Error(t *testing.T) {
+
+	body := `{"customer":{"name":"john"},"items":[{"quantity":1},{"quantity":"two"}]}`
+	req := httptest.NewRequest(http.MethodPost, "http://endpoint9/orders", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	var order decodeOrder
+	err := DecodeJSON(req, &order)
+
+	p, _ := ResolveProblemDetails(rec, req, err)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "/items/1/quantity", Extensions(p)[PointerExtension])
+	assert.Equal(t, "int", Extensions(p)[ExpectedTypeExtension])
+	assert.Equal(t, int64(strings.Index(body, `"two"`)+len(`"two"`)), Extensions(p)[OffsetExtension])
+	assert.Equal(t, []FieldError{{Pointer: "/items/1/quantity", Location: LocationBody, Reason: "must be int", Code: "invalid_type"}}, Extensions(p)[ErrorsExtension])
+}
+
+func TestDecodeJSON_Errors(t *testing.T) {
+
+	tests := []struct {
+		body    string
+		opts    []DecodeOption
+		status  int
+		pointer interface{}
+	}{
+		{`{"customer":{"name":["john"]}}`, nil, http.StatusUnprocessableEntity, "/customer/name"},
+		{`{"customer":{"name":"john"},"items":[{"quantity":1,}]}`, nil, http.StatusBadRequest, "/items/0/quantity"},
+		{`{"customer":{"na/me":"john"`, nil, http.StatusBadRequest, "/customer/na~1me"},
+		{`{"customer":{"name":"john"}} {}`, nil, http.StatusBadRequest, ""},
+		{``, nil, http.StatusBadRequest, nil},
+		{`{"note":"fast"}`, []DecodeOption{DisallowUnknownFields()}, http.StatusUnprocessableEntity, "/note"},
+		{`{"customer":{"name":"x","note":"y"}}`, []DecodeOption{DisallowUnknownFields()}, http.StatusUnprocessableEntity, "/customer/note"},
+		{`{"items":[{"quantity":1},{"Quantity":2,"note":"y"}],"note":"z"}`, []DecodeOption{DisallowUnknownFields()}, http.StatusUnprocessableEntity, "/items/1/note"},
+		{`{"customer":{"name":"john"}}`, []DecodeOption{MaxBodyBytes(8)}, http.StatusRequestEntityTooLarge, nil},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "http://endpoint9/orders", strings.NewReader(test.body))
+
+		var order decodeOrder
+		p := ToProblemDetails(req, DecodeJSON(req, &order, test.opts...))
+
+		assert.Equal(t, test.status, p.GetStatus(), test.body)
+		assert.Equal(t, test.pointer, Extensions(p)[PointerExtension], test.body)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "http://endpoint9/orders", strings.NewReader(`{"customer":{"name":"john"}}`))
+	var order decodeOrder
+
+	assert.NoError(t, DecodeJSON(req, &order))
+	assert.Equal(t, "john", order.Customer.Name)
+}
@@ -0,0 +1,307 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Encoder serialize problem details error with a media type
+type Encoder interface {
+	// ContentType media type of the serialized problem details
+	ContentType() string
+	// Encode write serialized problem details to w
+	Encode(w io.Writer, p ProblemDetailErr) error
+}
+
+type contentTypeEncoder struct {
+	contentType string
+	encoder     Encoder
+}
+
+type member struct {
+	name  string
+	value interface{}
+}
+
+// JSONOptions options of json encoder
+type JSONOptions struct {
+	// Marshal json codec of the encoder, e.g. Marshal of goccy/go-json, sonic or jsoniter, encoding/json is used when it isn't set
+	Marshal func(v interface{}) ([]byte, error)
+	// DisableHTMLEscaping keep <, > and & characters unescaped in json strings of encoding/json codec, it doesn't apply
+	// to values of Marshal which are escaped as the codec is configured
+	DisableHTMLEscaping bool
+	// Indent indentation of the serialized problem details, e.g. in development mode
+	Indent string
+}
+
+type jsonEncoder struct {
+	opts JSONOptions
+}
+
+// JSONEncoder serialize problem details error with application/problem+json media type,
+// extension members of ProblemDetail are serialized sorted by name after the standard members
+func JSONEncoder() Encoder {
+	return NewJSONEncoder(JSONOptions{})
+}
+
+// NewJSONEncoder serialize problem details error with application/problem+json media type with the options
+func NewJSONEncoder(opts JSONOptions) Encoder {
+	return jsonEncoder{opts: opts}
+}
+
+func (jsonEncoder) ContentType() string {
+	return "application/problem+json"
+}
+
+func (j jsonEncoder) Encode(w io.Writer, p ProblemDetailErr) error {
+	if pd, ok := p.(*ProblemDetail); ok && j.opts.Marshal == nil && j.opts.Indent == "" {
+		var b []byte
+		if buf, ok := w.(*bytes.Buffer); ok {
+			b = buf.AvailableBuffer()
+		}
+		b, err := appendProblemDetailJSON(b, pd, !j.opts.DisableHTMLEscaping)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+
+	val, err := j.marshal(p)
+	if err != nil {
+		return err
+	}
+
+	if j.opts.Indent != "" {
+		var buf bytes.Buffer
+		if err = json.Indent(&buf, val, "", j.opts.Indent); err != nil {
+			return err
+		}
+		val = buf.Bytes()
+	}
+	_, err = w.Write(val)
+	return err
+}
+
+// marshal serialize ProblemDetail with encoding/json, problems are flattened to their members for the codec and for
+// custom problems, so the codec serializes every member and extension instead of MarshalJSON of ProblemDetail, and
+// fields of the custom problem serialize next to the members of the problem it embeds, also when it embeds *ProblemDetail
+func (j jsonEncoder) marshal(p ProblemDetailErr) ([]byte, error) {
+	if _, ok := p.(*ProblemDetail); ok && j.opts.Marshal == nil {
+		return j.marshalValue(p)
+	}
+
+	b := []byte{'{'}
+	for i, m := range collectMembers(p, []member{
+		{"status", p.GetStatus()},
+		{"title", p.GetTitle()},
+		{"detail", p.GetDetails()},
+		{"type", p.GetType()},
+		{"instance", p.GetInstance()},
+		{"stackTrace", p.GetStackTrace()},
+	}) {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONString(b, m.name, !j.opts.DisableHTMLEscaping)
+		b = append(b, ':')
+
+		val, err := j.marshalValue(m.value)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, val...)
+	}
+	return append(b, '}'), nil
+}
+
+func (j jsonEncoder) marshalValue(v interface{}) ([]byte, error) {
+	if j.opts.Marshal != nil {
+		return j.opts.Marshal(v)
+	}
+	if j.opts.DisableHTMLEscaping {
+		return marshalJSON(v)
+	}
+	return json.Marshal(v)
+}
+
+// ContentTypeEncoder serialize problem details error with encoder under another media type, e.g. application/json
+func ContentTypeEncoder(contentType string, encoder Encoder) Encoder {
+	return contentTypeEncoder{contentType: contentType, encoder: encoder}
+}
+
+func (c contentTypeEncoder) ContentType() string {
+	return c.contentType
+}
+
+func (c contentTypeEncoder) Encode(w io.Writer, p ProblemDetailErr) error {
+	return c.encoder.Encode(w, p)
+}
+
+// Members flatten problem details error to its members, including fields of custom problem and extension members,
+// for encoders of other media types, numbers are converted to int64 or float64
+func Members(p ProblemDetailErr) (map[string]interface{}, error) {
+	members, err := problemMembers(p)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(members))
+	for _, m := range members {
+		result[m.name] = convertNumbers(m.value)
+	}
+	return result, nil
+}
+
+// FromMembers create problem details from its members, unknown members are collected as extensions
+func FromMembers(members map[string]interface{}) (*ProblemDetail, error) {
+	val, err := json.Marshal(members)
+	if err != nil {
+		return nil, err
+	}
+
+	var p ProblemDetail
+	if err := json.Unmarshal(val, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// problemMembers flatten standard members, fields of custom problem and extensions of problem details error,
+// values are normalized to json data model (map, slice, string, json.Number, bool and nil) for non json encoders
+func problemMembers(p ProblemDetailErr) ([]member, error) {
+	members := collectMembers(p, []member{
+		{"type", p.GetType()},
+		{"title", p.GetTitle()},
+		{"status", p.GetStatus()},
+		{"detail", p.GetDetails()},
+		{"instance", p.GetInstance()},
+		{"stackTrace", p.GetStackTrace()},
+	})
+	for i, m := range members {
+		value, err := normalizeValue(m.value)
+		if err != nil {
+			return nil, err
+		}
+		members[i].value = value
+	}
+	return members, nil
+}
+
+// collectMembers non empty standard members in their order, then fields of custom problem and extensions sorted by name
+func collectMembers(p ProblemDetailErr, standard []member) []member {
+	var members []member
+	for _, m := range standard {
+		if m.value != "" && m.value != 0 {
+			members = append(members, m)
+		}
+	}
+
+	var additional = customMembers(p)
+	var extensions = Extensions(p)
+	var names []string
+	for k := range extensions {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		if !standardMembers[k] {
+			additional = append(additional, member{k, extensions[k]})
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, m := range additional {
+		if seen[m.name] {
+			continue
+		}
+		seen[m.name] = true
+		members = append(members, m)
+	}
+	return members
+}
+
+// customMembers fields of custom problem details struct which embeds ProblemDetailErr
+func customMembers(p ProblemDetailErr) []member {
+	var members []member
+	customFields(p, func(name string, field reflect.Value, omitEmpty bool) {
+		if !omitEmpty || !field.IsZero() {
+			members = append(members, member{name, field.Interface()})
+		}
+	})
+	return members
+}
+
+// customFields call fn with the member name of exported fields of custom problem details struct, fields are settable
+// when the problem is a pointer
+func customFields(p ProblemDetailErr, fn func(name string, field reflect.Value, omitEmpty bool)) {
+	v := reflect.Indirect(reflect.ValueOf(p))
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if standardMembers[name] {
+			continue
+		}
+		fn(name, v.Field(i), strings.Contains(opts, "omitempty"))
+	}
+}
+
+func normalizeValue(value interface{}) (interface{}, error) {
+	switch value.(type) {
+	case nil, string, bool, int:
+		return value, nil
+	}
+
+	val, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized interface{}
+	decoder := json.NewDecoder(bytes.NewReader(val))
+	decoder.UseNumber()
+	if err := decoder.Decode(&normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+func convertNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case int:
+		return int64(v)
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = convertNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertNumbers(item)
+		}
+	}
+	return value
+}
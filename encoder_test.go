@@ -0,0 +1,137 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestJSONEncoder_Escape_HTML(t *testing.T) {
+
+	p := &ProblemDetail{Status: http.StatusBadRequest, Detail: "<b>name</b> & age are required"}
+	p.SetExtension("hint", "<i>name</i>")
+
+	var escaped, unescaped bytes.Buffer
+	_ = JSONEncoder().Encode(&escaped, p)
+	_ = NewJSONEncoder(JSONOptions{DisableHTMLEscaping: true}).Encode(&unescaped, p)
+
+	assert.Equal(t, `{"status":400,"detail":"\u003cb\u003ename\u003c/b\u003e \u0026 age are required","hint":"\u003ci\u003ename\u003c/i\u003e"}`, escaped.String())
+	assert.Equal(t, `{"status":400,"detail":"<b>name</b> & age are required","hint":"<i>name</i>"}`, unescaped.String())
+}
+
+func TestJSONEncoder_Indent_Sorted_Extensions(t *testing.T) {
+
+	p := &ProblemDetail{Status: http.StatusForbidden, Title: "out of credit"}
+	p.Extensions = map[string]interface{}{"zone": "eu", "balance": 30, "accounts": []string{"/account/12345"}}
+
+	var buf bytes.Buffer
+	_ = NewJSONEncoder(JSONOptions{Indent: "  "}).Encode(&buf, p)
+
+	assert.Equal(t, `{
+  "status": 403,
+  "title": "out of credit",
+  "accounts": [
+    "/account/12345"
+  ],
+  "balance": 30,
+  "zone": "eu"
+}`, buf.String())
+}
+
+func TestJSONEncoder_Custom_Problem(t *testing.T) {
+
+	embedded := &orderProblem{ProblemDetail: &ProblemDetail{Status: http.StatusConflict, Title: "conflict"}, Order: "<42>"}
+	embedded.SetExtension("balance", 30)
+	custom := &CustomProblemDetailTest{ProblemDetailErr: &ProblemDetail{Status: http.StatusConflict}, Description: "some description..."}
+
+	var buf bytes.Buffer
+	err := JSONEncoder().Encode(&buf, embedded)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"status":409,"title":"conflict","order":"\u003c42\u003e","balance":30}`, buf.String())
+
+	buf.Reset()
+	err = NewJSONEncoder(JSONOptions{Marshal: json.Marshal, DisableHTMLEscaping: true, Indent: " "}).Encode(&buf, custom)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":409,"description":"some description..."}`, buf.String())
+}
+
+func TestResolver_JSON_Codec(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+
+	var encoded []interface{}
+	resolver := NewResolver(WithJSONOptions(JSONOptions{Marshal: func(v interface{}) ([]byte, error) {
+		encoded = append(encoded, v)
+		if s, ok := v.(string); ok {
+			return []byte(strconv.QuoteToASCII(s)), nil
+		}
+		return json.Marshal(v)
+	}}))
+
+	err := &ProblemDetail{Status: http.StatusConflict, Detail: "café is closed"}
+	err.SetExtension("owner", map[string]interface{}{"name": "Renée"})
+
+	_, _ = resolver.ResolveProblemDetails(rec, req, err)
+
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"detail":"caf\u00e9 is closed"`)
+	assert.Contains(t, rec.Body.String(), `"owner":{"name":"Renée"}`)
+	assert.Contains(t, encoded, interface{}(map[string]interface{}{"name": "Renée"}))
+	for _, v := range encoded {
+		_, isProblem := v.(*ProblemDetail)
+		assert.False(t, isProblem)
+	}
+}
+
+func TestJSONEncoder_ProblemDetail_Matches_Reflection(t *testing.T) {
+
+	p := &ProblemDetail{
+		Status:     http.StatusBadRequest,
+		Title:      "bad \"request\"",
+		Detail:     "line\nbreak\ttab \x01 \b \f <b>&</b> \u2028 invalid \xff utf-8 \u00e9",
+		Type:       "https://errors.example.com/bad-request",
+		StackTrace: "main.go:42\\n",
+	}
+	p.Extensions = map[string]interface{}{"count": 3, "ratio": 0.5, "ok": true, "none": nil, "items": []string{"<a>"}, "title": "ignored"}
+
+	for _, escape := range []bool{true, false} {
+		var buf bytes.Buffer
+		err := NewJSONEncoder(JSONOptions{DisableHTMLEscaping: !escape}).Encode(&buf, p)
+
+		reflection, _ := marshalJSON(p)
+		if escape {
+			reflection, _ = json.Marshal(p)
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(reflection), buf.String())
+	}
+}
+
+func TestAppendJSONString_Matches_Encoding_JSON(t *testing.T) {
+
+	values := []string{
+		"",
+		"plain",
+		"quote \" backslash \\ slash /",
+		"\b\f\n\r\t\x00\x01\x1f\x7f",
+		"<script>&amp;</script>",
+		"\u2028\u2029 \u00e9 \U0001F600",
+		"invalid \xff\xfe utf-8 \xe2\x82",
+	}
+	for _, value := range values {
+		escaped, _ := json.Marshal(value)
+		unescaped, _ := marshalJSON(value)
+
+		assert.Equal(t, string(escaped), string(appendJSONString(nil, value, true)), value)
+		assert.Equal(t, string(unescaped), string(appendJSONString(nil, value, false)), value)
+	}
+}
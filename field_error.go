@@ -0,0 +1,75 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+)
+
+// ErrorsExtension extension member carrying the invalid fields of the request, like errors array of RFC 9457 examples
+const ErrorsExtension = "errors"
+
+// Location location of the invalid field in the request
+type Location string
+
+const (
+	LocationBody   Location = "body"
+	LocationQuery  Location = "query"
+	LocationHeader Location = "header"
+	LocationPath   Location = "path"
+)
+
+// FieldError invalid field of the request, the field of the body is identified by its JSON pointer and
+// the field of the query, header and path by its parameter name
+type FieldError struct {
+	// Pointer RFC 6901 JSON pointer of the field of the body, e.g. /items/0/quantity
+	Pointer string `json:"pointer,omitempty"`
+	// Parameter name of the query, header or path parameter
+	Parameter string `json:"parameter,omitempty"`
+	// Location location of the field in the request, it is omitted when it is unknown
+	Location Location `json:"location,omitempty"`
+	// Reason human-readable reason of the error, it is serialized as detail like RFC 9457 errors array
+	Reason string `json:"detail"`
+	// Code machine-readable code of the error, e.g. required
+	Code string `json:"code,omitempty"`
+	// Param parameter of the failed rule, e.g. 1 of gte=1 rule of go-playground/validator
+	Param string `json:"param,omitempty"`
+}
+
+// AttachFieldErrors append the field errors to errors extension member of the problem, attached errors of any shape
+// which decodes to field errors, e.g. of a problem decoded from json, are kept before them
+func AttachFieldErrors(p ProblemDetailErr, fieldErrors ...FieldError) ProblemDetailErr {
+	attached, _ := GetFieldErrors(p)
+	return SetExtension(p, ErrorsExtension, append(slices.Clip(attached), fieldErrors...))
+}
+
+// GetFieldErrors returns the field errors of errors extension member of the problem, also when the problem is decoded from json
+func GetFieldErrors(p ProblemDetailErr) ([]FieldError, error) {
+	value, ok := Extensions(p)[ErrorsExtension]
+	if !ok {
+		return nil, nil
+	}
+	if fieldErrors, ok := value.([]FieldError); ok {
+		return fieldErrors, nil
+	}
+
+	val, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var fieldErrors []FieldError
+	if err := json.Unmarshal(val, &fieldErrors); err != nil {
+		return nil, err
+	}
+	return fieldErrors, nil
+}
+
+// NewValidationProblem create 422 problem details error with the field errors
+func NewValidationProblem(fieldErrors ...FieldError) *ProblemDetail {
+	p := &ProblemDetail{
+		Status: http.StatusUnprocessableEntity,
+		Title:  http.StatusText(http.StatusUnprocessableEntity),
+	}
+	AttachFieldErrors(p, fieldErrors...)
+	return p
+}
@@ -0,0 +1,42 @@
+package problem
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestFieldErrors_JSON_Round_Trip(t *testing.T) {
+
+	p := NewValidationProblem(FieldError{Parameter: "page", Location: LocationQuery, Reason: "must be a positive integer", Code: "invalid_value"})
+	AttachFieldErrors(p, FieldError{Pointer: "/items/0/quantity", Location: LocationBody, Reason: "is required", Code: "required"})
+
+	val, err := json.Marshal(p)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":422,"title":"Unprocessable Entity","errors":[
+		{"parameter":"page","location":"query","detail":"must be a positive integer","code":"invalid_value"},
+		{"pointer":"/items/0/quantity","location":"body","detail":"is required","code":"required"}]}`, string(val))
+
+	var decoded ProblemDetail
+	_ = json.Unmarshal(val, &decoded)
+	fieldErrors, err := GetFieldErrors(&decoded)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, decoded.GetStatus())
+	assert.Equal(t, Extensions(p)[ErrorsExtension], fieldErrors)
+}
+
+func TestAttachFieldErrors_Merge_Decoded(t *testing.T) {
+
+	var decoded ProblemDetail
+	_ = json.Unmarshal([]byte(`{"status":422,"errors":[{"pointer":"/quantity","detail":"must be 1 or greater","code":"gte","param":"1"}]}`), &decoded)
+
+	AttachFieldErrors(&decoded, FieldError{Parameter: "page", Location: LocationQuery, Reason: "is required", Code: "required"})
+
+	assert.Equal(t, []FieldError{
+		{Pointer: "/quantity", Reason: "must be 1 or greater", Code: "gte", Param: "1"},
+		{Parameter: "page", Location: LocationQuery, Reason: "is required", Code: "required"},
+	}, decoded.GetExtensions()[ErrorsExtension])
+}
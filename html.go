@@ -0,0 +1,106 @@
+package problem
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+)
+
+// HTMLData problem details passed to the html template, Extensions holds fields of custom problem and extension members
+type HTMLData struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	StackTrace string
+	Extensions []HTMLMember
+}
+
+// HTMLMember extension member with its value formatted as text, non string values are formatted as json
+type HTMLMember struct {
+	Name  string
+	Value string
+}
+
+type htmlEncoder struct {
+	template *template.Template
+}
+
+var defaultHTMLTemplate = template.Must(template.New("problem").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{if .Status}}{{.Status}} {{end}}{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 3em auto; max-width: 48em; color: #222; }
+h1 { font-size: 1.6em; }
+dt { font-weight: bold; margin-top: .8em; }
+pre { background: #f4f4f4; padding: 1em; overflow-x: auto; }
+</style>
+</head>
+<body>
+<h1>{{if .Status}}{{.Status}} {{end}}{{.Title}}</h1>
+{{if .Detail}}<p>{{.Detail}}</p>
+{{end}}<dl>
+{{if .Type}}<dt>type</dt><dd>{{.Type}}</dd>
+{{end}}{{if .Instance}}<dt>instance</dt><dd>{{.Instance}}</dd>
+{{end}}{{range .Extensions}}<dt>{{.Name}}</dt><dd>{{.Value}}</dd>
+{{end}}</dl>
+{{if .StackTrace}}<pre>{{.StackTrace}}</pre>
+{{end}}</body>
+</html>
+`))
+
+// HTMLEncoder render problem details error as text/html error page for browser clients with the default template
+func HTMLEncoder() Encoder {
+	return HTMLTemplateEncoder(defaultHTMLTemplate)
+}
+
+// HTMLTemplateEncoder render problem details error as text/html error page with the template, the template is executed with HTMLData
+func HTMLTemplateEncoder(t *template.Template) Encoder {
+	return htmlEncoder{template: t}
+}
+
+func (htmlEncoder) ContentType() string {
+	return "text/html; charset=utf-8"
+}
+
+func (h htmlEncoder) Encode(w io.Writer, p ProblemDetailErr) error {
+	members, err := problemMembers(p)
+	if err != nil {
+		return err
+	}
+
+	data := HTMLData{
+		Type:       p.GetType(),
+		Title:      p.GetTitle(),
+		Status:     p.GetStatus(),
+		Detail:     p.GetDetails(),
+		Instance:   p.GetInstance(),
+		StackTrace: p.GetStackTrace(),
+	}
+	for _, m := range members {
+		if standardMembers[m.name] {
+			continue
+		}
+		value, err := formatValue(m.value)
+		if err != nil {
+			return err
+		}
+		data.Extensions = append(data.Extensions, HTMLMember{Name: m.name, Value: value})
+	}
+	return h.template.Execute(w, data)
+}
+
+// formatValue format normalized member value as text
+func formatValue(value interface{}) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	val, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(val), nil
+}
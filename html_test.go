@@ -0,0 +1,62 @@
+package problem
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTMLEncoder_Escaped(t *testing.T) {
+
+	p := &ProblemDetail{
+		Status:   http.StatusBadRequest,
+		Title:    "bad-request",
+		Detail:   "<script>alert('name')</script> is not a valid name",
+		Instance: "/orders?name=<b>",
+	}
+	p.SetExtension("balance", 30)
+	p.SetExtension("owner", "<i>admin</i>")
+
+	var buf bytes.Buffer
+	err := HTMLEncoder().Encode(&buf, p)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "<h1>400 bad-request</h1>")
+	assert.Contains(t, buf.String(), "<p>&lt;script&gt;alert(&#39;name&#39;)&lt;/script&gt; is not a valid name</p>")
+	assert.Contains(t, buf.String(), "<dt>instance</dt><dd>/orders?name=&lt;b&gt;</dd>")
+	assert.Contains(t, buf.String(), "<dt>balance</dt><dd>30</dd>")
+	assert.Contains(t, buf.String(), "<dt>owner</dt><dd>&lt;i&gt;admin&lt;/i&gt;</dd>")
+	assert.NotContains(t, buf.String(), "<script>")
+}
+
+func TestHTMLTemplateEncoder(t *testing.T) {
+
+	tmpl := template.Must(template.New("problem").Parse(`<h2>{{.Title}}</h2>{{range .Extensions}}<span>{{.Name}}={{.Value}}</span>{{end}}`))
+
+	p := &CustomProblemDetailTest{
+		ProblemDetailErr: &ProblemDetail{Status: http.StatusConflict, Title: "conflict"},
+		Description:      "some description...",
+	}
+
+	var buf bytes.Buffer
+	err := HTMLTemplateEncoder(tmpl).Encode(&buf, p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<h2>conflict</h2><span>description=some description...</span>", buf.String())
+}
+
+func TestNegotiate_Browser_HTML(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	rec := httptest.NewRecorder()
+
+	_, _ = ResolveProblemDetails(rec, req, endpoint3())
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "<h1>500 Internal Server Error</h1>")
+}
@@ -0,0 +1,117 @@
+package problem
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// RequestIDHeader header of the request id used by RequestIDInstance by default
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDExtension name of extension member of the request id attached by RequestIDInstance
+const RequestIDExtension = "requestId"
+
+// maxRequestIDLength length limit of the request ids which RequestIDInstance keeps
+const maxRequestIDLength = 128
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]+$`)
+
+// InstanceResolver resolve instance of problem details error which has no instance by the request, r can be nil
+type InstanceResolver interface {
+	ResolveInstance(r *http.Request) string
+}
+
+// InstanceResolverFunc function adapter of InstanceResolver
+type InstanceResolverFunc func(r *http.Request) string
+
+func (f InstanceResolverFunc) ResolveInstance(r *http.Request) string {
+	return f(r)
+}
+
+// RequestURIInstance resolve instance to the request URI with its query string, e.g. /orders/42?expand=items
+func RequestURIInstance() InstanceResolver {
+	return InstanceResolverFunc(func(r *http.Request) string {
+		if r == nil || r.URL == nil {
+			return ""
+		}
+		return r.URL.RequestURI()
+	})
+}
+
+// PathInstance resolve instance to the path of the request without its query string, e.g. /orders/42
+func PathInstance() InstanceResolver {
+	return InstanceResolverFunc(func(r *http.Request) string {
+		if r == nil || r.URL == nil {
+			return ""
+		}
+		return r.URL.EscapedPath()
+	})
+}
+
+// AbsoluteURLInstance resolve instance to the absolute URL of the request without its query string on the base URL,
+// e.g. https://api.example.com/orders/42 on https://api.example.com. When baseURL is empty the URL is built from Host
+// header and TLS state of the request, which must be trusted, e.g. Host validated by the reverse proxy, as clients can
+// send any Host
+func AbsoluteURLInstance(baseURL string) InstanceResolver {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return InstanceResolverFunc(func(r *http.Request) string {
+		if r == nil || r.URL == nil {
+			return ""
+		}
+		if baseURL != "" {
+			return baseURL + r.URL.EscapedPath()
+		}
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		host := r.Host
+		if host == "" {
+			host = r.URL.Host
+		}
+		return scheme + "://" + host + r.URL.EscapedPath()
+	})
+}
+
+// RequestIDInstance resolve instance to a random urn:uuid URN unique per occurrence, the request id in the header is
+// attached in RequestIDExtension extension member when it has RFC 3986 unreserved characters up to maxRequestIDLength.
+// X-Request-ID header is used when header is empty
+func RequestIDInstance(header string) InstanceResolver {
+	if header == "" {
+		header = RequestIDHeader
+	}
+	return requestIDInstance{header: header}
+}
+
+// requestIDInstance InstanceResolver of RequestIDInstance, the resolver attaches the request id of the request
+type requestIDInstance struct {
+	header string
+}
+
+func (i requestIDInstance) ResolveInstance(_ *http.Request) string {
+	return "urn:uuid:" + newUUID()
+}
+
+// requestID request id in the header of the request, empty when it is missing or invalid
+func (i requestIDInstance) requestID(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	id := strings.TrimSpace(r.Header.Get(i.header))
+	if len(id) > maxRequestIDLength || !requestIDPattern.MatchString(id) {
+		return ""
+	}
+	return id
+}
+
+// newUUID generate random UUID version 4
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
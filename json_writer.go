@@ -0,0 +1,200 @@
+package problem
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// maxQuotedCache bound of precomputed json strings of static members (type and title) of problem definitions
+const maxQuotedCache = 512
+
+// invalidUTF8 replacement of invalid UTF-8 bytes as encoding/json writes it, the escaped \ufffd or the replacement
+// character itself depending on the implementation of encoding/json of the toolchain
+var invalidUTF8 = func() []byte {
+	b, _ := json.Marshal("\xff")
+	return b[1 : len(b)-1]
+}()
+
+var quotedCache = struct {
+	sync.RWMutex
+	escaped   map[string][]byte
+	unescaped map[string][]byte
+}{escaped: map[string][]byte{}, unescaped: map[string][]byte{}}
+
+// appendProblemDetailJSON serialize ProblemDetail without reflection, output is the same as json.Marshal
+func appendProblemDetailJSON(b []byte, p *ProblemDetail, escapeHTML bool) ([]byte, error) {
+	b = append(b, '{')
+	var empty = true
+	appendName := func(name string) {
+		if !empty {
+			b = append(b, ',')
+		}
+		empty = false
+		b = append(b, '"')
+		b = append(b, name...)
+		b = append(b, '"', ':')
+	}
+
+	if p.Status != 0 {
+		appendName("status")
+		b = strconv.AppendInt(b, int64(p.Status), 10)
+	}
+	if p.Title != "" {
+		appendName("title")
+		b = appendCachedJSONString(b, p.Title, escapeHTML)
+	}
+	if p.Detail != "" {
+		appendName("detail")
+		b = appendJSONString(b, p.Detail, escapeHTML)
+	}
+	if p.Type != "" {
+		appendName("type")
+		b = appendCachedJSONString(b, p.Type, escapeHTML)
+	}
+	if p.Instance != "" {
+		appendName("instance")
+		b = appendJSONString(b, p.Instance, escapeHTML)
+	}
+	if p.StackTrace != "" {
+		appendName("stackTrace")
+		b = appendJSONString(b, p.StackTrace, escapeHTML)
+	}
+
+	if len(p.Extensions) > 0 {
+		names := make([]string, 0, len(p.Extensions))
+		for k := range p.Extensions {
+			if !standardMembers[k] {
+				names = append(names, k)
+			}
+		}
+		slices.Sort(names)
+
+		for _, k := range names {
+			if !empty {
+				b = append(b, ',')
+			}
+			empty = false
+			b = appendJSONString(b, k, escapeHTML)
+			b = append(b, ':')
+
+			var err error
+			if b, err = appendJSONValue(b, p.Extensions[k], escapeHTML); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return append(b, '}'), nil
+}
+
+func appendJSONValue(b []byte, value interface{}, escapeHTML bool) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(b, "null"...), nil
+	case string:
+		return appendJSONString(b, v, escapeHTML), nil
+	case bool:
+		return strconv.AppendBool(b, v), nil
+	case int:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(b, v, 10), nil
+	}
+
+	var val []byte
+	var err error
+	if escapeHTML {
+		val, err = json.Marshal(value)
+	} else {
+		val, err = marshalJSON(value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(b, val...), nil
+}
+
+// appendCachedJSONString append json string of static members from the precomputed bytes
+func appendCachedJSONString(b []byte, s string, escapeHTML bool) []byte {
+	quotedCache.RLock()
+	cache := quotedCache.unescaped
+	if escapeHTML {
+		cache = quotedCache.escaped
+	}
+	quoted, ok := cache[s]
+	full := len(cache) >= maxQuotedCache
+	quotedCache.RUnlock()
+	if ok {
+		return append(b, quoted...)
+	}
+
+	start := len(b)
+	b = appendJSONString(b, s, escapeHTML)
+	if full {
+		return b
+	}
+
+	quotedCache.Lock()
+	if len(cache) < maxQuotedCache {
+		cache[s] = append([]byte(nil), b[start:]...)
+	}
+	quotedCache.Unlock()
+	return b
+}
+
+// appendJSONString append json string with the same escaping as encoding/json, byte for byte
+func appendJSONString(b []byte, s string, escapeHTML bool) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && (!escapeHTML || (c != '<' && c != '>' && c != '&')) {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = append(b, invalidUTF8...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
@@ -0,0 +1,141 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// TruncationMarker marker appended to the truncated values
+const TruncationMarker = "…(truncated)"
+
+// SizeLimits max sizes in bytes of the problem details, zero is unlimited
+type SizeLimits struct {
+	// Detail max size of detail member
+	Detail int
+	// StackTrace max size of stackTrace member and of json of the frames in stack extension member
+	StackTrace int
+	// Extension max size of string values of the extension members
+	Extension int
+	// Body max size of the encoded response body, stack trace, extensions and detail are dropped in order until the body
+	// fits, then the problem is reduced to its standard members and at last to its status and title
+	Body int
+}
+
+// truncate truncate detail, stack trace, stack frames and string extensions of the problem to their limits
+func (l SizeLimits) truncate(p ProblemDetailErr) {
+	if l.Detail > 0 {
+		p.SetDetail(truncateString(p.GetDetails(), l.Detail))
+	}
+	if l.StackTrace > 0 {
+		p.SetStackTrace(truncateString(p.GetStackTrace(), l.StackTrace))
+		if frames, ok := Extensions(p)[StackExtension].([]StackFrame); ok {
+			Extensions(p)[StackExtension] = truncateFrames(frames, l.StackTrace)
+		}
+	}
+	if l.Extension > 0 {
+		for name, value := range Extensions(p) {
+			if s, ok := value.(string); ok {
+				Extensions(p)[name] = truncateString(s, l.Extension)
+			}
+		}
+	}
+}
+
+// encode encode the problem into the buffer within the body limit by dropping stack trace, extensions and detail in order,
+// the problem is replaced with its standard members and at last with its status and title when its other members
+// don't fit, an error is returned when even they don't fit
+func (l SizeLimits) encode(buf *bytes.Buffer, encoder Encoder, p ProblemDetailErr) error {
+	if err := encoder.Encode(buf, p); err != nil || l.Body <= 0 || buf.Len() <= l.Body {
+		return err
+	}
+
+	reencode := func() error {
+		buf.Reset()
+		return encoder.Encode(buf, p)
+	}
+	detail := func() error {
+		return l.fit(buf, encoder, p, p.GetDetails, func(s string) { p.SetDetail(s) })
+	}
+
+	shrinks := []func() error{
+		func() error {
+			p.SetStackTrace("")
+			delete(Extensions(p), StackExtension)
+			delete(Extensions(p), CausesExtension)
+			return reencode()
+		},
+		func() error {
+			for name := range Extensions(p) {
+				delete(Extensions(p), name)
+			}
+			return reencode()
+		},
+		detail,
+		func() error {
+			p = &ProblemDetail{Status: p.GetStatus(), Title: p.GetTitle(), Detail: p.GetDetails(), Type: p.GetType(), Instance: p.GetInstance()}
+			if err := reencode(); err != nil {
+				return err
+			}
+			return detail()
+		},
+		func() error {
+			p = &ProblemDetail{Status: p.GetStatus(), Title: p.GetTitle()}
+			if err := reencode(); err != nil {
+				return err
+			}
+			return l.fit(buf, encoder, p, p.GetTitle, func(s string) { p.SetTitle(s) })
+		},
+	}
+	for _, shrink := range shrinks {
+		if err := shrink(); err != nil || buf.Len() <= l.Body {
+			return err
+		}
+	}
+	return fmt.Errorf("problem details body of %d bytes exceeds the limit of %d bytes", buf.Len(), l.Body)
+}
+
+// fit truncate the member by the overflow of the encoded body until the body fits or the member is empty, the overflow
+// is measured in encoded bytes, which are more than the bytes of the member when its characters are escaped
+func (l SizeLimits) fit(buf *bytes.Buffer, encoder Encoder, p ProblemDetailErr, get func() string, set func(string)) error {
+	for buf.Len() > l.Body && get() != "" {
+		set(truncateString(get(), len(get())-(buf.Len()-l.Body)))
+
+		buf.Reset()
+		if err := encoder.Encode(buf, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// truncateFrames keep the innermost stack frames whose json size is within max bytes
+func truncateFrames(frames []StackFrame, max int) []StackFrame {
+	size := 2
+	for i, frame := range frames {
+		val, err := json.Marshal(frame)
+		if err != nil {
+			return frames[:i]
+		}
+		if size += len(val) + 1; size > max+1 {
+			return frames[:i]
+		}
+	}
+	return frames
+}
+
+// truncateString truncate the string to max bytes with the marker on a rune boundary
+func truncateString(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	cut := max - len(TruncationMarker)
+	if cut <= 0 {
+		return ""
+	}
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + TruncationMarker
+}
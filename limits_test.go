@@ -0,0 +1,88 @@
+package problem
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestResolver_Size_Limits(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+	rec := httptest.NewRecorder()
+
+	resolver := NewResolver(WithSizeLimits(SizeLimits{Detail: 40, StackTrace: 20, Extension: 18}))
+
+	err := &ProblemDetail{Status: http.StatusBadRequest, Detail: strings.Repeat("validation failed ü ", 1000), StackTrace: strings.Repeat("main.go:42\n", 100)}
+	err.SetExtension("dump", strings.Repeat("€", 100))
+
+	p, _ := resolver.ResolveProblemDetails(rec, req, err)
+
+	assert.LessOrEqual(t, len(p.GetDetails()), 40)
+	assert.True(t, strings.HasSuffix(p.GetDetails(), TruncationMarker))
+	assert.True(t, utf8.ValidString(p.GetDetails()))
+	assert.LessOrEqual(t, len(p.GetStackTrace()), 20)
+	assert.Equal(t, "€"+TruncationMarker, Extensions(p)["dump"])
+}
+
+func TestResolver_Body_Limit(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+
+	newErr := func() error {
+		return (&ProblemDetail{Status: http.StatusBadRequest, Title: "bad-request", Detail: strings.Repeat("x", 200), StackTrace: strings.Repeat("main.go:42\n", 100)}).
+			SetExtension("dump", strings.Repeat("y", 1000)).(error)
+	}
+
+	for _, limit := range []int{2000, 1000, 300, 100} {
+		rec := httptest.NewRecorder()
+		_, _ = NewResolver(WithSizeLimits(SizeLimits{Body: limit})).ResolveProblemDetails(rec, req, newErr())
+
+		assert.LessOrEqual(t, rec.Body.Len(), limit)
+		assert.Contains(t, rec.Body.String(), `"title":"bad-request"`)
+	}
+
+	rec := httptest.NewRecorder()
+	_, _ = NewResolver(WithSizeLimits(SizeLimits{Body: 200})).ResolveProblemDetails(rec, req, newErr())
+
+	assert.NotContains(t, rec.Body.String(), "stackTrace")
+	assert.NotContains(t, rec.Body.String(), "dump")
+	assert.Contains(t, rec.Body.String(), TruncationMarker)
+
+	for _, limit := range []int{300, 120, 60} {
+		req := httptest.NewRequest(http.MethodGet, "http://endpoint3/orders?filter="+strings.Repeat("z", 500), nil)
+		rec := httptest.NewRecorder()
+
+		err := &ProblemDetail{Status: http.StatusBadRequest, Title: "bad-request", Detail: strings.Repeat("<b>", 100)}
+		_, resolveErr := NewResolver(WithSizeLimits(SizeLimits{Body: limit})).ResolveProblemDetails(rec, req, err)
+
+		assert.NoError(t, resolveErr)
+		assert.LessOrEqual(t, rec.Body.Len(), limit)
+		assert.Contains(t, rec.Body.String(), `"status":400`)
+	}
+
+	_, err := NewResolver(WithSizeLimits(SizeLimits{Body: 10})).ResolveProblemDetails(httptest.NewRecorder(), req, newErr())
+
+	assert.Error(t, err)
+}
+
+func TestSizeLimits_Stack_Frames(t *testing.T) {
+
+	var frames []StackFrame
+	for i := 0; i < 10; i++ {
+		frames = append(frames, StackFrame{Function: "orders.(*Service).Place", File: "orders/service.go", Line: 40 + i})
+	}
+	p := (&ProblemDetail{Status: http.StatusInternalServerError}).SetExtension(StackExtension, frames)
+
+	SizeLimits{StackTrace: 200}.truncate(p)
+
+	truncated := Extensions(p)[StackExtension].([]StackFrame)
+	val, _ := json.Marshal(truncated)
+
+	assert.Equal(t, frames[:2], truncated)
+	assert.LessOrEqual(t, len(val), 200)
+}
@@ -0,0 +1,190 @@
+package problem
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+)
+
+// StatusClientClosedRequest status code of requests canceled by the client before the response
+const StatusClientClosedRequest = 499
+
+// Mapping map errors matching Match to problem details error on the resolver, mappings with higher priority are
+// matched first, and are matched after Map and before MapStatus mappings
+type Mapping struct {
+	// Name name of the mapping, WithMappings replaces the installed mapping with the same name
+	Name string
+	// Priority priority of the mapping, mappings with the same priority are matched in order of installation
+	Priority int
+	// Match report whether the error is mapped by the mapping
+	Match func(err error) bool
+	// Problem create problem details error of the error, err.Error() is used as detail when it has no detail
+	Problem func(err error) ProblemDetailErr
+}
+
+// MatchIs match errors which are the target in their chain
+func MatchIs(target error) func(err error) bool {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
+// MatchAs match errors which have T in their chain
+func MatchAs[T error]() func(err error) bool {
+	return func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}
+}
+
+// StandardMappings mappings of the errors of standard library, install them with WithMappings
+func StandardMappings() []Mapping {
+	return []Mapping{
+		{
+			Name:    "context.DeadlineExceeded",
+			Match:   MatchIs(context.DeadlineExceeded),
+			Problem: statusProblem(http.StatusGatewayTimeout),
+		},
+		{
+			Name:  "context.Canceled",
+			Match: MatchIs(context.Canceled),
+			Problem: func(error) ProblemDetailErr {
+				return &ProblemDetail{Status: StatusClientClosedRequest, Title: "Client Closed Request"}
+			},
+		},
+		{
+			Name:  "http.MaxBytesError",
+			Match: MatchAs[*http.MaxBytesError](),
+			Problem: func(err error) ProblemDetailErr {
+				var maxBytesErr *http.MaxBytesError
+				errors.As(err, &maxBytesErr)
+				return (&ProblemDetail{
+					Status: http.StatusRequestEntityTooLarge,
+					Detail: fmt.Sprintf("request body is larger than %d bytes", maxBytesErr.Limit),
+				}).SetExtension("limit", maxBytesErr.Limit)
+			},
+		},
+		jsonSyntaxMapping(),
+		jsonTypeMapping(),
+		{
+			Name:    "os.ErrNotExist",
+			Match:   MatchIs(os.ErrNotExist),
+			Problem: detailProblem(http.StatusNotFound, "resource is not found"),
+		},
+		{
+			Name:    "os.ErrPermission",
+			Match:   MatchIs(os.ErrPermission),
+			Problem: detailProblem(http.StatusForbidden, "permission is denied"),
+		},
+		{
+			Name:    "sql.ErrNoRows",
+			Match:   MatchIs(sql.ErrNoRows),
+			Problem: statusProblem(http.StatusNotFound),
+		},
+		{
+			Name: "net.Error.Timeout",
+			Match: func(err error) bool {
+				var netErr net.Error
+				return errors.As(err, &netErr) && netErr.Timeout()
+			},
+			Problem: statusProblem(http.StatusGatewayTimeout),
+		},
+	}
+}
+
+// jsonSyntaxMapping mapping of json.SyntaxError to 400 problem with the offset of the malformed json
+func jsonSyntaxMapping() Mapping {
+	return Mapping{
+		Name:  "json.SyntaxError",
+		Match: MatchAs[*json.SyntaxError](),
+		Problem: func(err error) ProblemDetailErr {
+			var syntaxErr *json.SyntaxError
+			errors.As(err, &syntaxErr)
+			return &ProblemDetail{
+				Status:     http.StatusBadRequest,
+				Detail:     fmt.Sprintf("request body has malformed json at offset %d: %s", syntaxErr.Offset, syntaxErr.Error()),
+				Extensions: map[string]interface{}{OffsetExtension: syntaxErr.Offset},
+			}
+		},
+	}
+}
+
+// jsonTypeMapping mapping of json.UnmarshalTypeError to 422 problem with the invalid field, as DecodeJSON resolves it
+func jsonTypeMapping() Mapping {
+	return Mapping{
+		Name:  "json.UnmarshalTypeError",
+		Match: MatchAs[*json.UnmarshalTypeError](),
+		Problem: func(err error) ProblemDetailErr {
+			var typeErr *json.UnmarshalTypeError
+			errors.As(err, &typeErr)
+			p := &ProblemDetail{
+				Status:     http.StatusUnprocessableEntity,
+				Detail:     fmt.Sprintf("request body has json %s for field %q which must be %s", typeErr.Value, typeErr.Field, typeErr.Type),
+				Extensions: map[string]interface{}{OffsetExtension: typeErr.Offset},
+			}
+			return AttachFieldErrors(p, FieldError{
+				Pointer:  JSONPointer(typeErr.Field),
+				Location: LocationBody,
+				Reason:   fmt.Sprintf("must be %s", typeErr.Type),
+				Code:     "invalid_type",
+			})
+		},
+	}
+}
+
+func statusProblem(statusCode int) func(error) ProblemDetailErr {
+	return func(error) ProblemDetailErr {
+		return &ProblemDetail{Status: statusCode}
+	}
+}
+
+// detailProblem problem with fixed detail, for errors whose message has internals, e.g. file paths of os.PathError
+func detailProblem(statusCode int, detail string) func(error) ProblemDetailErr {
+	return func(error) ProblemDetailErr {
+		return &ProblemDetail{Status: statusCode, Detail: detail}
+	}
+}
+
+// installMappings install the mappings on the resolver, mappings replace installed mappings with the same name, it
+// panics on mappings without Match or Problem
+func (rs *Resolver) installMappings(mappings []Mapping) {
+	for _, mapping := range mappings {
+		if mapping.Match == nil || mapping.Problem == nil {
+			panic(fmt.Sprintf("problem: mapping %q must have Match and Problem", mapping.Name))
+		}
+		i := slices.IndexFunc(rs.mappings, func(m Mapping) bool {
+			return m.Name != "" && m.Name == mapping.Name
+		})
+		if i >= 0 {
+			rs.mappings[i] = mapping
+			continue
+		}
+		rs.mappings = append(rs.mappings, mapping)
+	}
+	slices.SortStableFunc(rs.mappings, func(a, b Mapping) int {
+		return cmp.Compare(b.Priority, a.Priority)
+	})
+}
+
+func (rs *Resolver) setMappings(r *http.Request, err error) ProblemDetailErr {
+	for _, mapping := range rs.mappings {
+		if !mapping.Match(err) {
+			continue
+		}
+
+		prob := mapping.Problem(err)
+		if prob.GetDetails() == "" {
+			prob.SetDetail(err.Error())
+		}
+		rs.setProblemDefaults(prob, err, r)
+		return prob
+	}
+	return nil
+}
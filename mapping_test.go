@@ -0,0 +1,114 @@
+package problem
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestResolver_Standard_Mappings(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodPost, "http://endpoint7/orders", nil)
+	resolver := NewResolver(WithMappings(StandardMappings()...))
+
+	_, notExistErr := os.Open("/not-exist")
+	_, maxBytesErr := io.ReadAll(http.MaxBytesReader(httptest.NewRecorder(), io.NopCloser(strings.NewReader("0123456789")), 4))
+	syntaxErr := json.Unmarshal([]byte(`{"quantity":}`), &struct{}{})
+	typeErr := json.Unmarshal([]byte(`{"quantity":"two"}`), &struct {
+		Quantity int `json:"quantity"`
+	}{})
+
+	tests := []struct {
+		err    error
+		status int
+		title  string
+	}{
+		{fmt.Errorf("query orders: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, "Gateway Timeout"},
+		{context.Canceled, StatusClientClosedRequest, "Client Closed Request"},
+		{maxBytesErr, http.StatusRequestEntityTooLarge, "Request Entity Too Large"},
+		{syntaxErr, http.StatusBadRequest, "Bad Request"},
+		{typeErr, http.StatusUnprocessableEntity, "Unprocessable Entity"},
+		{notExistErr, http.StatusNotFound, "Not Found"},
+		{fmt.Errorf("open report: %w", os.ErrPermission), http.StatusForbidden, "Forbidden"},
+		{fmt.Errorf("find order: %w", sql.ErrNoRows), http.StatusNotFound, "Not Found"},
+		{&timeoutError{}, http.StatusGatewayTimeout, "Gateway Timeout"},
+	}
+	for _, test := range tests {
+		p := resolver.ToProblemDetails(req, test.err)
+
+		assert.Equal(t, test.status, p.GetStatus(), test.err.Error())
+		assert.Equal(t, test.title, p.GetTitle(), test.err.Error())
+	}
+
+	p := resolver.ToProblemDetails(req, maxBytesErr)
+	assert.Equal(t, int64(4), Extensions(p)["limit"])
+
+	p = resolver.ToProblemDetails(req, syntaxErr)
+	assert.Equal(t, int64(13), Extensions(p)["offset"])
+
+	p = resolver.ToProblemDetails(req, typeErr)
+	assert.Equal(t, []FieldError{{Pointer: "/quantity", Location: LocationBody, Reason: "must be int", Code: "invalid_type"}}, Extensions(p)[ErrorsExtension])
+
+	p = resolver.ToProblemDetails(req, notExistErr)
+	assert.Equal(t, "resource is not found", p.GetDetails())
+	assert.NotContains(t, p.GetDetails(), "/not-exist")
+
+	assert.Equal(t, http.StatusInternalServerError, ToProblemDetails(req, sql.ErrNoRows).GetStatus())
+}
+
+func TestResolver_Mappings_Priority(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint7/orders/42", nil)
+
+	goneMapping := Mapping{
+		Name:     "sql.ErrNoRows",
+		Priority: 10,
+		Match:    MatchIs(sql.ErrNoRows),
+		Problem: func(err error) ProblemDetailErr {
+			return &ProblemDetail{Status: http.StatusGone, Detail: "order is removed"}
+		},
+	}
+	notFoundMapping := Mapping{
+		Name:     "order-not-found",
+		Priority: 1,
+		Match:    MatchIs(sql.ErrNoRows),
+		Problem: func(err error) ProblemDetailErr {
+			return &ProblemDetail{Status: http.StatusNotFound}
+		},
+	}
+	resolver := NewResolver(WithMappings(StandardMappings()...), WithMappings(notFoundMapping, goneMapping))
+
+	p := resolver.ToProblemDetails(req, sql.ErrNoRows)
+
+	assert.Equal(t, http.StatusGone, p.GetStatus())
+	assert.Equal(t, "order is removed", p.GetDetails())
+}
+
+func TestResolver_Mappings_Without_Match(t *testing.T) {
+
+	assert.PanicsWithValue(t, `problem: mapping "order-not-found" must have Match and Problem`, func() {
+		NewResolver(WithMappings(Mapping{Name: "order-not-found", Problem: statusProblem(http.StatusNotFound)}))
+	})
+}
+
+type timeoutError struct{}
+
+func (t *timeoutError) Error() string {
+	return "dial tcp 10.0.0.1:5432: i/o timeout"
+}
+
+func (t *timeoutError) Timeout() bool {
+	return true
+}
+
+func (t *timeoutError) Temporary() bool {
+	return true
+}
@@ -0,0 +1,30 @@
+package problem
+
+import (
+	"net/http"
+)
+
+// Mode mode of the resolver which decides how much of the error is exposed in the response
+type Mode int
+
+const (
+	// DevelopmentMode expose stack trace and detail of all errors, the default mode of the resolver
+	DevelopmentMode Mode = iota
+	// ProductionMode omit stack trace and replace detail of server errors with GenericDetail
+	ProductionMode
+)
+
+// GenericDetail detail of server errors in production mode
+const GenericDetail = "An unexpected error occurred while processing the request."
+
+// LogHook receive the error and its problem details with the full information before it is exposed, r can be nil
+type LogHook func(r *http.Request, err error, p ProblemDetailErr)
+
+// hideInternals remove information of the problem which can leak internals of the service in production mode
+func hideInternals(p ProblemDetailErr) {
+	p.SetStackTrace("")
+	delete(Extensions(p), StackExtension)
+	if p.GetStatus() >= http.StatusInternalServerError {
+		p.SetDetail(GenericDetail)
+	}
+}
@@ -0,0 +1,91 @@
+package problem
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type mediaRange struct {
+	typ     string
+	subtype string
+	q       float64
+}
+
+// negotiate select encoder base on q-values of Accept header of the request, the default encoder is used when
+// Accept header is missing or several encoders have the same quality, and the fallback encoder when none of them is acceptable
+func (rs *Resolver) negotiate(r *http.Request) Encoder {
+	if r == nil || len(rs.encoders) == 0 {
+		return rs.encoder
+	}
+	accept := r.Header.Values("Accept")
+	if len(accept) == 0 {
+		return rs.encoder
+	}
+	ranges := parseAccept(strings.Join(accept, ","))
+
+	var selected Encoder
+	var selectedQ float64
+	for _, encoder := range append([]Encoder{rs.encoder}, rs.encoders...) {
+		if q := acceptQuality(ranges, encoder.ContentType()); q > selectedQ {
+			selected, selectedQ = encoder, q
+		}
+	}
+	if selected == nil {
+		return rs.fallback
+	}
+	return selected
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, q: q})
+	}
+	return ranges
+}
+
+// acceptQuality quality of the content type by the most specific matched media range
+func acceptQuality(ranges []mediaRange, contentType string) float64 {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return 0
+	}
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+
+	var q float64
+	var specificity = -1
+	for _, mr := range ranges {
+		var s int
+		switch {
+		case mr.typ == typ && mr.subtype == subtype:
+			s = 2
+		case mr.typ == typ && mr.subtype == "*":
+			s = 1
+		case mr.typ == "*" && mr.subtype == "*":
+			s = 0
+		default:
+			continue
+		}
+		if s > specificity {
+			q, specificity = mr.q, s
+		}
+	}
+	return q
+}
@@ -0,0 +1,64 @@
+package problem
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiate_Accept(t *testing.T) {
+
+	resolver := NewResolver()
+
+	tests := []struct {
+		accept      string
+		contentType string
+	}{
+		{"", "application/problem+json"},
+		{"*/*", "application/problem+json"},
+		{"application/problem+xml", "application/problem+xml"},
+		{"application/json", "application/json"},
+		{"application/json;q=0.5, application/problem+xml;q=0.9", "application/problem+xml"},
+		{"application/*;q=0.8, application/problem+json;q=0", "application/problem+xml"},
+		{"text/csv", "application/problem+json"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+
+		assert.Equal(t, test.contentType, resolver.negotiate(req).ContentType(), test.accept)
+	}
+}
+
+func TestNegotiate_Fallback(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+	req.Header.Set("Accept", "image/png")
+	rec := httptest.NewRecorder()
+
+	resolver := NewResolver(WithFallbackEncoder(ContentTypeEncoder("application/json", JSONEncoder())))
+
+	_, _ = resolver.ResolveProblemDetails(rec, req, endpoint3())
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+}
+
+func TestNegotiate_Without_Encoders(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+	req.Header.Set("Accept", "application/problem+xml")
+	rec := httptest.NewRecorder()
+
+	resolver := NewResolver(WithEncoders())
+
+	_, _ = resolver.ResolveProblemDetails(rec, req, endpoint3())
+
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "", rec.Header().Get("Vary"))
+}
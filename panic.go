@@ -0,0 +1,188 @@
+package problem
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+)
+
+// PanicError error of a recovered panic with the stack of the panicking goroutine, map it with Map[*PanicError]
+type PanicError struct {
+	// Value value passed to panic
+	Value interface{}
+	// Stack stack of the panicking goroutine formatted by runtime/debug.Stack
+	Stack []byte
+
+	pcs []uintptr
+}
+
+// NewPanicError create PanicError of the recovered value, it must be called in the deferred function which recovers the panic
+func NewPanicError(value interface{}) *PanicError {
+	pcs := make([]uintptr, 64)
+	return &PanicError{
+		Value: value,
+		Stack: debug.Stack(),
+		pcs:   pcs[:runtime.Callers(2, pcs)],
+	}
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", p.Value)
+}
+
+// Unwrap returns the panic value when it is an error
+func (p *PanicError) Unwrap() error {
+	if err, ok := p.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// StackTrace program counters of the stack of the panicking goroutine, in the shape of pkg/errors stack trace
+func (p *PanicError) StackTrace() []uintptr {
+	return p.pcs
+}
+
+// Format formats the panic with its stack for %+v
+func (p *PanicError) Format(s fmt.State, verb rune) {
+	_, _ = io.WriteString(s, p.Error())
+	if verb == 'v' && s.Flag('+') {
+		_, _ = io.WriteString(s, "\n")
+		_, _ = s.Write(p.Stack)
+	}
+}
+
+// Recover recover panics of the handler and resolve them to problem details error with DefaultResolver
+func Recover(next http.Handler) http.Handler {
+	return DefaultResolver.Recover(next)
+}
+
+// Recover recover panics of the handler and resolve them to problem details error, the problem isn't written
+// when the handler has already committed the response, but the log hook still receives the panic with its problem
+func (rs *Resolver) Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &recoverResponseWriter{ResponseWriter: w}
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+			if value == http.ErrAbortHandler {
+				panic(value)
+			}
+
+			panicErr := NewPanicError(value)
+			if rw.committed {
+				_, _ = rs.resolve(nil, r, panicErr)
+				return
+			}
+			_, _ = rs.ResolveProblemDetails(w, r, panicErr)
+		}()
+
+		next.ServeHTTP(rw.withInterfaces(), r)
+	})
+}
+
+// recoverResponseWriter track whether the handler has committed the response
+type recoverResponseWriter struct {
+	http.ResponseWriter
+	committed bool
+}
+
+func (w *recoverResponseWriter) WriteHeader(statusCode int) {
+	w.committed = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *recoverResponseWriter) Write(data []byte) (int, error) {
+	w.committed = true
+	return w.ResponseWriter.Write(data)
+}
+
+// Unwrap returns the underlying response writer for http.ResponseController
+func (w *recoverResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// withInterfaces wrap the writer with the optional interfaces of the underlying writer, http.Flusher, http.Hijacker and
+// io.ReaderFrom, so handlers streaming events or upgrading to websocket keep working behind Recover
+func (w *recoverResponseWriter) withInterfaces() http.ResponseWriter {
+	_, isFlusher := w.ResponseWriter.(http.Flusher)
+	_, isHijacker := w.ResponseWriter.(http.Hijacker)
+	_, isReaderFrom := w.ResponseWriter.(io.ReaderFrom)
+
+	switch {
+	case isFlusher && isHijacker && isReaderFrom:
+		return struct {
+			*recoverResponseWriter
+			flusher
+			hijacker
+			readerFrom
+		}{w, flusher{w}, hijacker{w}, readerFrom{w}}
+	case isFlusher && isHijacker:
+		return struct {
+			*recoverResponseWriter
+			flusher
+			hijacker
+		}{w, flusher{w}, hijacker{w}}
+	case isFlusher && isReaderFrom:
+		return struct {
+			*recoverResponseWriter
+			flusher
+			readerFrom
+		}{w, flusher{w}, readerFrom{w}}
+	case isHijacker && isReaderFrom:
+		return struct {
+			*recoverResponseWriter
+			hijacker
+			readerFrom
+		}{w, hijacker{w}, readerFrom{w}}
+	case isFlusher:
+		return struct {
+			*recoverResponseWriter
+			flusher
+		}{w, flusher{w}}
+	case isHijacker:
+		return struct {
+			*recoverResponseWriter
+			hijacker
+		}{w, hijacker{w}}
+	case isReaderFrom:
+		return struct {
+			*recoverResponseWriter
+			readerFrom
+		}{w, readerFrom{w}}
+	}
+	return w
+}
+
+type flusher struct {
+	w *recoverResponseWriter
+}
+
+func (f flusher) Flush() {
+	f.w.committed = true
+	f.w.ResponseWriter.(http.Flusher).Flush()
+}
+
+type hijacker struct {
+	w *recoverResponseWriter
+}
+
+func (h hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.w.committed = true
+	return h.w.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+type readerFrom struct {
+	w *recoverResponseWriter
+}
+
+func (r readerFrom) ReadFrom(src io.Reader) (int64, error) {
+	r.w.committed = true
+	return r.w.ResponseWriter.(io.ReaderFrom).ReadFrom(src)
+}
@@ -0,0 +1,155 @@
+package problem
+
+import (
+	"bufio"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint6/orders", nil)
+	rec := httptest.NewRecorder()
+
+	var logged ProblemDetailErr
+	stackOptions := StackOptions{Filter: func(frame StackFrame) bool {
+		return strings.HasSuffix(frame.Function, ".panicOrder")
+	}}
+	resolver := NewResolver(WithStackFrames(stackOptions), WithLogHook(func(r *http.Request, err error, p ProblemDetailErr) {
+		logged = p
+	}))
+
+	handler := resolver.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panicOrder()
+	}))
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "panic: order is nil", logged.GetDetails())
+
+	frames := Extensions(logged)[StackExtension].([]StackFrame)
+	assert.Len(t, frames, 1)
+	assert.True(t, strings.HasSuffix(frames[0].File, "panic_test.go"))
+}
+
+func TestRecover_Map_PanicError(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint6/orders", nil)
+	rec := httptest.NewRecorder()
+
+	Map[*PanicError](func() ProblemDetailErr {
+		return &ProblemDetail{Status: http.StatusServiceUnavailable, Title: "panic"}
+	})
+	defer delete(mappers, reflect.TypeOf(&PanicError{}))
+
+	handler := Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panicOrder()
+	}))
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"title":"panic"`)
+	assert.Contains(t, rec.Body.String(), "goroutine")
+}
+
+func TestRecover_Committed_Response(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint6/orders", nil)
+	rec := httptest.NewRecorder()
+
+	handler := Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("partial"))
+		panicOrder()
+	}))
+
+	assert.NotPanics(t, func() {
+		handler.ServeHTTP(rec, req)
+	})
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "partial", rec.Body.String())
+}
+
+func TestRecover_Committed_Response_Log_Hook(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint6/orders", nil)
+	rec := httptest.NewRecorder()
+
+	var logged error
+	var loggedProblem ProblemDetailErr
+	resolver := NewResolver(WithLogHook(func(r *http.Request, err error, p ProblemDetailErr) {
+		logged, loggedProblem = err, p
+	}))
+
+	handler := resolver.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+		panicOrder()
+	}))
+	handler.ServeHTTP(rec, req)
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, logged, &panicErr)
+	assert.Equal(t, "order is nil", panicErr.Value)
+	assert.Equal(t, http.StatusInternalServerError, loggedProblem.GetStatus())
+	assert.Equal(t, "partial", rec.Body.String())
+}
+
+func panicOrder() {
+	panic("order is nil")
+}
+
+// hijackRecorder response writer supporting http.Hijacker but not http.Flusher
+type hijackRecorder struct {
+	http.ResponseWriter
+	hijacked bool
+}
+
+func (h *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.hijacked = true
+	return nil, nil, nil
+}
+
+func TestRecover_Optional_Interfaces(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint6/events", nil)
+	rec := httptest.NewRecorder()
+
+	handler := Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, isHijacker := w.(http.Hijacker)
+		assert.False(t, isHijacker)
+
+		flusher, ok := w.(http.Flusher)
+		assert.True(t, ok)
+		flusher.Flush()
+		panicOrder()
+	}))
+
+	assert.NotPanics(t, func() {
+		handler.ServeHTTP(rec, req)
+	})
+	assert.True(t, rec.Flushed)
+	assert.Empty(t, rec.Body.String())
+
+	hijack := &hijackRecorder{ResponseWriter: httptest.NewRecorder()}
+	handler = Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, isFlusher := w.(http.Flusher)
+		assert.False(t, isFlusher)
+
+		hijacker, ok := w.(http.Hijacker)
+		assert.True(t, ok)
+		_, _, _ = hijacker.Hijack()
+		panicOrder()
+	}))
+
+	assert.NotPanics(t, func() {
+		handler.ServeHTTP(hijack, req)
+	})
+	assert.True(t, hijack.hijacked)
+	assert.Empty(t, hijack.ResponseWriter.(*httptest.ResponseRecorder).Body.String())
+}
@@ -1,15 +1,11 @@
 package problem
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/gin-gonic/gin"
-	"github.com/gofiber/fiber/v3"
-	"github.com/labstack/echo/v4"
-	"github.com/pkg/errors"
 	"net/http"
-	"net/http/httptest"
-	"net/url"
 	"reflect"
 )
 
@@ -20,12 +16,8 @@ type ProblemDetail struct {
 	Type       string `json:"type,omitempty"`
 	Instance   string `json:"instance,omitempty"`
 	StackTrace string `json:"stackTrace,omitempty"`
-}
-
-type fiberResponseWriter struct {
-	ctx     fiber.Ctx
-	written bool
-	headers http.Header
+	// Extensions additional members of the problem details which serialize next to the standard members
+	Extensions map[string]interface{} `json:"-"`
 }
 
 var mappers = map[reflect.Type]func() ProblemDetailErr{}
@@ -47,6 +39,15 @@ type ProblemDetailErr interface {
 	GetStackTrace() string
 }
 
+// ExtensionsHolder problem details error which carries extension members, ProblemDetail implements it and custom
+// problems which embed a ProblemDetail carry its extensions
+type ExtensionsHolder interface {
+	SetExtension(name string, value interface{}) ProblemDetailErr
+	GetExtensions() map[string]interface{}
+}
+
+var standardMembers = map[string]bool{"status": true, "title": true, "detail": true, "type": true, "instance": true, "stackTrace": true}
+
 func (p *ProblemDetail) SetDetail(detail string) ProblemDetailErr {
 	p.Detail = detail
 
@@ -107,16 +108,140 @@ func (p *ProblemDetail) GetStackTrace() string {
 	return p.StackTrace
 }
 
-func writeTo(w http.ResponseWriter, p ProblemDetailErr) (int, error) {
+func (p *ProblemDetail) SetExtension(name string, value interface{}) ProblemDetailErr {
+	if p.Extensions == nil {
+		p.Extensions = map[string]interface{}{}
+	}
+	p.Extensions[name] = value
+
+	return p
+}
+
+func (p *ProblemDetail) GetExtensions() map[string]interface{} {
+	return p.Extensions
+}
+
+// Extensions extension members of the problem details error, nil when it doesn't hold extensions
+func Extensions(p ProblemDetailErr) map[string]interface{} {
+	if holder := extensionsHolder(p); holder != nil {
+		return holder.GetExtensions()
+	}
+	return nil
+}
+
+// DecodeExtension decode extension member of the problem details error into target through its json form, e.g. of
+// problems decoded from json whose members are maps, it reports whether the member exists and is decoded
+func DecodeExtension(p ProblemDetailErr, name string, target interface{}) bool {
+	value, ok := Extensions(p)[name]
+	if !ok {
+		return false
+	}
+	val, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(val, target) == nil
+}
+
+// SetExtension set extension member of the problem details error, it is skipped when the problem doesn't hold extensions
+func SetExtension(p ProblemDetailErr, name string, value interface{}) ProblemDetailErr {
+	if holder := extensionsHolder(p); holder != nil {
+		holder.SetExtension(name, value)
+	}
+	return p
+}
+
+// extensionsHolder the problem itself or its embedded problem which holds the extensions
+func extensionsHolder(p ProblemDetailErr) ExtensionsHolder {
+	if holder, ok := p.(ExtensionsHolder); ok {
+		return holder
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(p))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).Anonymous || !v.Field(i).CanInterface() || v.Field(i).IsZero() {
+			continue
+		}
+		if embedded, ok := v.Field(i).Interface().(ProblemDetailErr); ok {
+			if holder := extensionsHolder(embedded); holder != nil {
+				return holder
+			}
+		}
+	}
+	return nil
+}
+
+func (p *ProblemDetail) Error() string {
+	if p.Detail != "" {
+		return p.Detail
+	}
+	if p.Title != "" {
+		return p.Title
+	}
+	return http.StatusText(p.Status)
+}
+
+// MarshalJSON serialize problem details with extension members sorted by name next to the standard members,
+// html characters are left unescaped for the encoder of the resolver. The method is promoted to custom problems
+// which embed *ProblemDetail, json encoders of the resolver serialize them with their own fields
+func (p *ProblemDetail) MarshalJSON() ([]byte, error) {
+	type problemDetail ProblemDetail
+	val, err := marshalJSON((*problemDetail)(p))
+	if err != nil {
+		return nil, err
+	}
 
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(p.GetStatus())
+	extensions := map[string]interface{}{}
+	for k, v := range p.Extensions {
+		if !standardMembers[k] {
+			extensions[k] = v
+		}
+	}
+	if len(extensions) == 0 {
+		return val, nil
+	}
 
-	val, err := json.Marshal(p)
+	ext, err := marshalJSON(extensions)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	if len(val) == 2 {
+		return ext, nil
+	}
+	val[len(val)-1] = ','
+	return append(val, ext[1:]...), nil
+}
+
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalJSON deserialize problem details and collect unknown members as extensions
+func (p *ProblemDetail) UnmarshalJSON(data []byte) error {
+	type problemDetail ProblemDetail
+	if err := json.Unmarshal(data, (*problemDetail)(p)); err != nil {
+		return err
+	}
+
+	var members map[string]interface{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for k, v := range members {
+		if !standardMembers[k] {
+			p.SetExtension(k, v)
+		}
 	}
-	return w.Write(val)
+	return nil
 }
 
 // MapStatus map status code to problem details error
@@ -129,180 +254,166 @@ func Map[T error](funcProblem func() ProblemDetailErr) {
 	mappers[reflect.TypeOf(*new(T))] = funcProblem
 }
 
-// ResolveProblemDetails retrieve and resolve error with format problem details error
+// ResolveProblemDetails retrieve and resolve error with format problem details error with DefaultResolver
 func ResolveProblemDetails(w http.ResponseWriter, r *http.Request, err error) (ProblemDetailErr, error) {
-	var errorMsg string = ""
+	return DefaultResolver.ResolveProblemDetails(w, r, err)
+}
+
+// ToProblemDetails resolve error with format problem details error without writing it to response with DefaultResolver,
+// r can be nil when the error doesn't belong to a http request
+func ToProblemDetails(r *http.Request, err error) ProblemDetailErr {
+	return DefaultResolver.ToProblemDetails(r, err)
+}
+
+// resolveProblem returns the resolved problem and the error itself when it is unhandled by the mappings
+func (rs *Resolver) resolveProblem(w http.ResponseWriter, r *http.Request, err error) (ProblemDetailErr, error) {
 	var statusCode int = http.StatusInternalServerError
-	var echoError *echo.HTTPError
-	var ginError *gin.Error
-	var fiberError *fiber.Error
-
-	if errors.As(err, &fiberError) {
-		statusCode = fiberError.Code
-		err = errors.New(fiberError.Message)
-	} else if errors.As(err, &echoError) {
-		statusCode = echoError.Code
-		if echoErr, ok := echoError.Message.(error); ok {
-			err = echoErr
-		} else if messageStr, ok := echoError.Message.(string); ok {
-			err = errors.New(messageStr)
-		}
-		if echoError.Internal != nil {
-			errorMsg = err.Error()
-			err = echoError.Internal
-		}
-	} else if errors.As(err, &ginError) {
-		var rw, ok = w.(gin.ResponseWriter)
-		if ok && rw.Written() {
-			statusCode = rw.Status()
-		}
-		if gin.Mode() == gin.TestMode {
-			var rw = w.(*httptest.ResponseRecorder)
-			if rw.Code != http.StatusOK {
-				statusCode = rw.Code
-			}
-		}
-		err = err.(*gin.Error).Err.(error)
+
+	var code, errorMsg, cause = unwrapError(w, err, rs.unwrappers)
+	if code != 0 {
+		statusCode = code
+	}
+	err = cause
+
+	if problemErr := rs.setProblemError(r, err); problemErr != nil {
+		return problemErr, nil
+	}
+
+	if mapCustomType := rs.setMapCustomType(r, err); mapCustomType != nil {
+		return mapCustomType, nil
 	}
 
-	var mapCustomType, mapCustomTypeErr = setMapCustomType(w, r, err)
-	if mapCustomType != nil {
-		return mapCustomType, mapCustomTypeErr
+	if mapping := rs.setMappings(r, err); mapping != nil {
+		return mapping, nil
 	}
 
-	var mapStatus, mapStatusErr = setMapStatusCode(w, r, err, statusCode)
-	if mapStatus != nil {
-		return mapStatus, mapStatusErr
+	if mapStatus := rs.setMapStatusCode(r, err, statusCode); mapStatus != nil {
+		return mapStatus, nil
 	}
 
-	var p, probError = setDefaultProblemDetails(w, r, err, errorMsg, statusCode)
-	if probError != nil {
-		return nil, probError
+	return rs.setDefaultProblemDetails(r, err, errorMsg, statusCode), err
+}
+
+func (rs *Resolver) setProblemError(r *http.Request, err error) ProblemDetailErr {
+	var prob ProblemDetailErr
+	if !errors.As(err, &prob) {
+		return nil
+	}
+
+	prob = cloneProblem(prob)
+	if prob.GetDetails() == "" {
+		prob.SetDetail(err.Error())
 	}
-	return p, err
+	rs.setProblemDefaults(prob, err, r)
+	return prob
 }
 
-func setMapCustomType(w http.ResponseWriter, r *http.Request, err error) (ProblemDetailErr, error) {
+// cloneProblem copy of the problem details error with its own extensions which the resolver fills and changes,
+// so problems shared between requests, e.g. sentinel errors, stay unchanged
+func cloneProblem(p ProblemDetailErr) ProblemDetailErr {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return p
+	}
 
-	problemCustomType := mappers[reflect.TypeOf(err)]
-	if problemCustomType != nil {
-		prob := problemCustomType()
-
-		validationProblems(prob, err, r)
-
-		for k, v := range mapperStatus {
-			if k == prob.GetStatus() {
-				_, err = writeTo(w, v())
-				if err != nil {
-					return nil, err
-				}
-				return prob, err
+	c := reflect.New(v.Elem().Type()).Elem()
+	c.Set(v.Elem())
+	for i := 0; i < c.NumField(); i++ {
+		field := c.Field(i)
+		if !field.CanSet() || field.IsZero() {
+			continue
+		}
+		if field.Kind() == reflect.Map {
+			clone := reflect.MakeMapWithSize(field.Type(), field.Len())
+			for iter := field.MapRange(); iter.Next(); {
+				clone.SetMapIndex(iter.Key(), iter.Value())
 			}
+			field.Set(clone)
+			continue
 		}
-
-		_, err = writeTo(w, prob)
-		if err != nil {
-			return nil, err
+		if inner, ok := field.Interface().(ProblemDetailErr); ok {
+			field.Set(reflect.ValueOf(cloneProblem(inner)))
 		}
-		return prob, err
 	}
-	return nil, err
+	return c.Addr().Interface().(ProblemDetailErr)
+}
+
+func (rs *Resolver) setMapCustomType(r *http.Request, err error) ProblemDetailErr {
+	problemCustomType := mappers[reflect.TypeOf(err)]
+	if problemCustomType == nil {
+		return nil
+	}
+
+	prob := problemCustomType()
+	rs.validationProblems(prob, err, r)
+
+	if problemStatus := mapperStatus[prob.GetStatus()]; problemStatus != nil {
+		prob = problemStatus()
+		rs.validationProblems(prob, err, r)
+	}
+	return prob
 }
 
-func setMapStatusCode(w http.ResponseWriter, r *http.Request, err error, statusCode int) (ProblemDetailErr, error) {
+func (rs *Resolver) setMapStatusCode(r *http.Request, err error, statusCode int) ProblemDetailErr {
 	problemStatus := mapperStatus[statusCode]
-	if problemStatus != nil {
-		prob := problemStatus()
-		validationProblems(prob, err, r)
-		_, err = writeTo(w, prob)
-		if err != nil {
-			return nil, err
-		}
-		return prob, err
+	if problemStatus == nil {
+		return nil
 	}
-	return nil, err
+
+	prob := problemStatus()
+	rs.validationProblems(prob, err, r)
+	return prob
 }
 
-func setDefaultProblemDetails(w http.ResponseWriter, r *http.Request, err error, errorMsg string, statusCode int) (ProblemDetailErr, error) {
-	defaultProblem := func() ProblemDetailErr {
-		if errorMsg == "" {
-			errorMsg = err.Error()
-		}
-		return &ProblemDetail{
-			Type:       getDefaultType(statusCode),
-			Status:     statusCode,
-			Detail:     errorMsg,
-			Title:      http.StatusText(statusCode),
-			Instance:   r.URL.RequestURI(),
-			StackTrace: errorsWithStack(err),
-		}
+func (rs *Resolver) setDefaultProblemDetails(r *http.Request, err error, errorMsg string, statusCode int) ProblemDetailErr {
+	if errorMsg == "" {
+		errorMsg = err.Error()
 	}
-	prob := defaultProblem()
-	_, err = writeTo(w, prob)
-	if err != nil {
-		return nil, err
+	prob := &ProblemDetail{
+		Status: statusCode,
+		Detail: errorMsg,
 	}
-	return prob, err
+	rs.setProblemDefaults(prob, err, r)
+	return prob
 }
 
-func validationProblems(problem ProblemDetailErr, err error, r *http.Request) {
+func (rs *Resolver) validationProblems(problem ProblemDetailErr, err error, r *http.Request) {
 	problem.SetDetail(err.Error())
+	rs.setProblemDefaults(problem, err, r)
+}
 
+func (rs *Resolver) setProblemDefaults(problem ProblemDetailErr, err error, r *http.Request) {
 	if problem.GetStatus() == 0 {
 		problem.SetStatus(http.StatusInternalServerError)
 	}
 	if problem.GetInstance() == "" {
-		problem.SetInstance(r.URL.RequestURI())
+		problem.SetInstance(rs.instanceResolver.ResolveInstance(r))
+	}
+	if requestID, ok := rs.instanceResolver.(requestIDInstance); ok {
+		if _, exists := Extensions(problem)[RequestIDExtension]; !exists {
+			if id := requestID.requestID(r); id != "" {
+				SetExtension(problem, RequestIDExtension, id)
+			}
+		}
 	}
 	if problem.GetType() == "" {
-		problem.SetType(getDefaultType(problem.GetStatus()))
+		problem.SetType(rs.typeResolver.ResolveType(problem.GetStatus()))
 	}
 	if problem.GetTitle() == "" {
 		problem.SetTitle(http.StatusText(problem.GetStatus()))
 	}
+	if rs.stackOptions != nil {
+		if _, ok := Extensions(problem)[StackExtension]; !ok {
+			SetExtension(problem, StackExtension, StackFrames(err, *rs.stackOptions))
+		}
+		return
+	}
 	if problem.GetStackTrace() == "" {
 		problem.SetStackTrace(errorsWithStack(err))
 	}
 }
 
-func getDefaultType(statusCode int) string {
-	return fmt.Sprintf("https://httpstatuses.io/%d", statusCode)
-}
-
 func errorsWithStack(err error) string {
 	res := fmt.Sprintf("%+v", err)
 	return res
 }
-
-func Response(c fiber.Ctx) *fiberResponseWriter {
-	return &fiberResponseWriter{
-		ctx:     c,
-		headers: make(http.Header),
-	}
-}
-
-func (f *fiberResponseWriter) Header() http.Header {
-	return f.headers
-}
-
-func (f *fiberResponseWriter) Write(data []byte) (int, error) {
-	f.written = true
-	return f.ctx.Response().BodyWriter().Write(data)
-}
-
-func (f *fiberResponseWriter) WriteHeader(statusCode int) {
-	f.written = true
-	f.ctx.Status(statusCode)
-}
-
-func Request(c fiber.Ctx) *http.Request {
-	fiberURI := c.Request().URI()
-	parsedURL, _ := url.Parse(string(fiberURI.FullURI()))
-
-	return &http.Request{
-		Method:     c.Method(),
-		URL:        parsedURL,
-		Header:     make(http.Header),
-		RequestURI: string(c.Request().RequestURI()),
-	}
-}
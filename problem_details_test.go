@@ -1,28 +1,23 @@
 package problem
 
 import (
+	"encoding/json"
 	"errors"
-	"github.com/gin-gonic/gin"
-	"github.com/gofiber/fiber/v3"
-	"github.com/labstack/echo/v4"
-	custom_errors "github.com/meysamhadeli/problem-details/samples/custom-errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
-	"github.com/valyala/fasthttp"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 )
 
-func TestMap_CustomType_Echo(t *testing.T) {
+func TestMap_CustomType(t *testing.T) {
 
-	e := echo.New()
-	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint1", nil)
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint1", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
 
-	err := echo_endpoint1(c)
+	err := endpoint1()
 
-	Map[custom_errors.BadRequestError](func() ProblemDetailErr {
+	Map[badRequestError](func() ProblemDetailErr {
 		return &ProblemDetail{
 			Status: http.StatusBadRequest,
 			Title:  "bad-request",
@@ -30,26 +25,25 @@ func TestMap_CustomType_Echo(t *testing.T) {
 		}
 	})
 
-	p, _ := ResolveProblemDetails(c.Response(), c.Request(), err)
+	p, _ := ResolveProblemDetails(rec, req, err)
 
-	assert.Equal(t, http.StatusBadRequest, c.Response().Status)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
 
 	assert.Equal(t, err.Error(), p.GetDetails())
 	assert.Equal(t, "bad-request", p.GetTitle())
-	assert.Equal(t, "https://httpstatuses.io/400", p.GetType())
+	assert.Equal(t, AboutBlank, p.GetType())
 	assert.Equal(t, http.StatusBadRequest, p.GetStatus())
 }
 
-func TestMap_Custom_Problem_Err_Echo(t *testing.T) {
+func TestMap_Custom_Problem_Err(t *testing.T) {
 
-	e := echo.New()
-	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint4", nil)
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint4", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
 
-	err := echo_endpoint4(c)
+	err := endpoint4()
 
-	Map[custom_errors.ConflictError](func() ProblemDetailErr {
+	Map[conflictError](func() ProblemDetailErr {
 		return &CustomProblemDetailTest{
 			ProblemDetailErr: &ProblemDetail{
 				Status: http.StatusConflict,
@@ -61,430 +55,210 @@ func TestMap_Custom_Problem_Err_Echo(t *testing.T) {
 		}
 	})
 
-	p, _ := ResolveProblemDetails(c.Response(), c.Request(), err)
+	p, _ := ResolveProblemDetails(rec, req, err)
 	cp := p.(*CustomProblemDetailTest)
 
-	assert.Equal(t, http.StatusConflict, c.Response().Status)
+	assert.Equal(t, http.StatusConflict, rec.Code)
 
 	assert.Equal(t, err.Error(), cp.GetDetails())
 	assert.Equal(t, "conflict", cp.GetTitle())
-	assert.Equal(t, "https://httpstatuses.io/409", cp.GetType())
+	assert.Equal(t, AboutBlank, cp.GetType())
 	assert.Equal(t, http.StatusConflict, cp.GetStatus())
 	assert.Equal(t, "some description...", cp.Description)
 	assert.Equal(t, "some additional info...", cp.AdditionalInfo)
 }
 
-func TestMap_Status_Echo(t *testing.T) {
+func TestMap_Status_Unwrapper(t *testing.T) {
 
-	e := echo.New()
-	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint2", nil)
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint2", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
 
-	err := echo_endpoint2(c)
+	resolver := NewResolver(WithUnwrappers(UnwrapAs(func(err statusError) (int, string, error) {
+		return err.Code, "", err.InternalError
+	})))
 
-	MapStatus(http.StatusBadGateway, func() ProblemDetailErr {
-		return &ProblemDetail{
-			Status: http.StatusUnauthorized,
-			Title:  "unauthorized",
-			Detail: err.Error(),
-		}
-	})
-
-	p, _ := ResolveProblemDetails(c.Response(), c.Request(), err)
-
-	assert.Equal(t, http.StatusUnauthorized, c.Response().Status)
-
-	assert.Equal(t, "We have a specific status code error in our endpoint", p.GetDetails())
-	assert.Equal(t, "unauthorized", p.GetTitle())
-	assert.Equal(t, "https://httpstatuses.io/401", p.GetType())
-	assert.Equal(t, http.StatusUnauthorized, p.GetStatus())
-}
-
-func TestMap_Status2_Echo(t *testing.T) {
-
-	e := echo.New()
-	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint2", nil)
-	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-
-	err := echo_endpoint2b(c)
+	err := endpoint2()
 
 	MapStatus(http.StatusBadGateway, func() ProblemDetailErr {
 		return &ProblemDetail{
 			Status: http.StatusUnauthorized,
 			Title:  "unauthorized",
-			Detail: err.Error(),
 		}
 	})
 
-	p, _ := ResolveProblemDetails(c.Response(), c.Request(), err)
+	p, _ := resolver.ResolveProblemDetails(rec, req, err)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 
-	assert.Equal(t, c.Response().Status, http.StatusUnauthorized)
 	assert.Equal(t, "We have a specific status code error in our endpoint", p.GetDetails())
 	assert.Equal(t, "unauthorized", p.GetTitle())
-	assert.Equal(t, "https://httpstatuses.io/401", p.GetType())
+	assert.Equal(t, AboutBlank, p.GetType())
 	assert.Equal(t, http.StatusUnauthorized, p.GetStatus())
 }
 
-func TestMap_Status_Details_Echo(t *testing.T) {
-
-	e := echo.New()
-	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint2", nil)
-	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-
-	err := echo.HTTPError{Code: http.StatusNotFound, Message: "Entity not found. Please contact admin.", Internal: errors.New("Error with additional analysis/audit logging information")}
-
-	MapStatus(http.StatusBadGateway, func() ProblemDetailErr {
-		return &ProblemDetail{
-			Status: http.StatusNotFound,
-			Title:  "Not Found",
-			Detail: err.Error(),
-		}
-	})
-
-	p, _ := ResolveProblemDetails(c.Response(), c.Request(), &err)
-
-	assert.Equal(t, http.StatusNotFound, c.Response().Status)
-	assert.Equal(t, "Entity not found. Please contact admin.", p.GetDetails())
-	assert.Equal(t, "Not Found", p.GetTitle())
-	assert.Equal(t, "https://httpstatuses.io/404", p.GetType())
-	assert.Equal(t, http.StatusNotFound, p.GetStatus())
-}
-
-func TestMap_Unhandled_Err_Echo(t *testing.T) {
+func TestMap_Unhandled_Err(t *testing.T) {
 
-	e := echo.New()
-	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint3", nil)
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
 
-	err := echo_endpoint3(c)
+	err := endpoint3()
 
-	p, _ := ResolveProblemDetails(c.Response(), c.Request(), err)
+	p, _ := ResolveProblemDetails(rec, req, err)
 
-	assert.Equal(t, http.StatusInternalServerError, c.Response().Status)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 
 	assert.Equal(t, err.Error(), p.GetDetails())
 	assert.Equal(t, "Internal Server Error", p.GetTitle())
-	assert.Equal(t, "https://httpstatuses.io/500", p.GetType())
+	assert.Equal(t, AboutBlank, p.GetType())
 	assert.Equal(t, http.StatusInternalServerError, p.GetStatus())
 }
 
-func TestMap_CustomType_Gin(t *testing.T) {
-
-	gin.SetMode(gin.TestMode)
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	r := gin.Default()
-
-	r.GET("/gin_endpoint1", func(ctx *gin.Context) {
-		err := errors.New("We have a custom type error in our endpoint")
-		customBadRequestError := custom_errors.BadRequestError{InternalError: err}
-		_ = c.Error(customBadRequestError)
-	})
-
-	req, _ := http.NewRequest(http.MethodGet, "/gin_endpoint1", nil)
-	r.ServeHTTP(w, req)
-
-	for _, err := range c.Errors {
-
-		Map[custom_errors.BadRequestError](func() ProblemDetailErr {
-			return &ProblemDetail{
-				Status: http.StatusBadRequest,
-				Title:  "bad-request",
-				Detail: err.Error(),
-			}
-		})
-
-		p, _ := ResolveProblemDetails(w, req, err)
-
-		assert.Equal(t, http.StatusBadRequest, p.GetStatus())
-		assert.Equal(t, err.Error(), p.GetDetails())
-		assert.Equal(t, "bad-request", p.GetTitle())
-		assert.Equal(t, "https://httpstatuses.io/400", p.GetType())
-	}
-}
+func TestResolve_Problem_Err(t *testing.T) {
 
-func TestMap_Custom_Problem_Err_Gin(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint5", nil)
+	rec := httptest.NewRecorder()
 
-	gin.SetMode(gin.TestMode)
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	r := gin.Default()
+	err := fmt.Errorf("lookup order: %w", &ProblemDetail{Status: http.StatusNotFound, Detail: "order 42 not found"})
 
-	r.GET("/gin_endpoint4", func(ctx *gin.Context) {
-		err := errors.New("We have a custom error with custom problem details error in our endpoint")
-		customConflictError := custom_errors.ConflictError{InternalError: err}
-		_ = c.Error(customConflictError)
-	})
+	p, _ := ResolveProblemDetails(rec, req, err)
 
-	req, _ := http.NewRequest(http.MethodGet, "/gin_endpoint4", nil)
-	r.ServeHTTP(w, req)
-
-	for _, err := range c.Errors {
-
-		Map[custom_errors.ConflictError](func() ProblemDetailErr {
-			return &CustomProblemDetailTest{
-				ProblemDetailErr: &ProblemDetail{
-					Status: http.StatusConflict,
-					Title:  "conflict",
-					Detail: err.Error(),
-				},
-				AdditionalInfo: "some additional info...",
-				Description:    "some description...",
-			}
-		})
-
-		p, _ := ResolveProblemDetails(w, req, err)
-		cp := p.(*CustomProblemDetailTest)
-
-		assert.Equal(t, http.StatusConflict, cp.GetStatus())
-		assert.Equal(t, err.Error(), cp.GetDetails())
-		assert.Equal(t, "conflict", cp.GetTitle())
-		assert.Equal(t, "https://httpstatuses.io/409", cp.GetType())
-		assert.Equal(t, "some description...", cp.Description)
-		assert.Equal(t, "some additional info...", cp.AdditionalInfo)
-	}
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "order 42 not found", p.GetDetails())
+	assert.Equal(t, "Not Found", p.GetTitle())
+	assert.Equal(t, AboutBlank, p.GetType())
 }
 
-func TestMap_Status_Gin(t *testing.T) {
+func TestResolve_Shared_Problem_Err_Unchanged(t *testing.T) {
 
-	gin.SetMode(gin.TestMode)
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	r := gin.Default()
+	errNotFound := (&ProblemDetail{Status: http.StatusNotFound}).SetExtension("resource", "order").(*ProblemDetail)
+	errConflict := &orderProblem{ProblemDetail: &ProblemDetail{Status: http.StatusConflict}, Order: "42"}
 
-	r.GET("/gin_endpoint2", func(ctx *gin.Context) {
-		err := errors.New("We have a specific status code error in our endpoint")
-		_ = c.AbortWithError(http.StatusBadGateway, err)
-	})
-
-	req, _ := http.NewRequest(http.MethodGet, "/gin_endpoint2", nil)
-	r.ServeHTTP(w, req)
+	for _, uri := range []string{"http://endpoint5/orders/1?token=secret", "http://endpoint5/orders/2"} {
+		req := httptest.NewRequest(http.MethodGet, uri, nil)
 
-	for _, err := range c.Errors {
+		p, _ := ResolveProblemDetails(httptest.NewRecorder(), req, fmt.Errorf("lookup order: %w", errNotFound))
+		SetExtension(p, "attempt", uri)
 
-		MapStatus(http.StatusBadGateway, func() ProblemDetailErr {
-			return &ProblemDetail{
-				Status: http.StatusUnauthorized,
-				Title:  "unauthorized",
-				Detail: err.Error(),
-			}
-		})
+		assert.Equal(t, req.URL.RequestURI(), p.GetInstance())
+		assert.Equal(t, "order", Extensions(p)["resource"])
 
-		p, _ := ResolveProblemDetails(w, req, err)
+		op := ToProblemDetails(req, errConflict).(*orderProblem)
 
-		assert.Equal(t, http.StatusUnauthorized, p.GetStatus())
-		assert.Equal(t, err.Error(), p.GetDetails())
-		assert.Equal(t, "unauthorized", p.GetTitle())
-		assert.Equal(t, "https://httpstatuses.io/401", p.GetType())
+		assert.Equal(t, req.URL.RequestURI(), op.GetInstance())
+		assert.Equal(t, "42", op.Order)
 	}
-}
-
-func TestMap_Unhandled_Err_Gin(t *testing.T) {
-
-	gin.SetMode(gin.TestMode)
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	r := gin.Default()
-
-	r.GET("/gin_endpoint3", func(ctx *gin.Context) {
-		err := errors.New("We have a unhandeled error in our endpoint")
-		_ = c.Error(err)
-	})
-
-	req, _ := http.NewRequest(http.MethodGet, "/gin_endpoint3", nil)
-	r.ServeHTTP(w, req)
-
-	for _, err := range c.Errors {
-
-		p, _ := ResolveProblemDetails(w, req, err)
 
-		assert.Equal(t, http.StatusInternalServerError, p.GetStatus())
-		assert.Equal(t, err.Error(), p.GetDetails())
-		assert.Equal(t, "Internal Server Error", p.GetTitle())
-		assert.Equal(t, "https://httpstatuses.io/500", p.GetType())
-	}
+	assert.Equal(t, &ProblemDetail{Status: http.StatusNotFound, Extensions: map[string]interface{}{"resource": "order"}}, errNotFound)
+	assert.Equal(t, &ProblemDetail{Status: http.StatusConflict}, errConflict.ProblemDetail)
 }
 
-func TestMap_CustomType_Fiber(t *testing.T) {
-	app := fiber.New()
-
-	app.Get("/fiber_endpoint1", func(c fiber.Ctx) error {
-		return fiber_endpoint1(c)
-	})
-
-	// Create fasthttp request context
-	fctx := &fasthttp.RequestCtx{}
-	fctx.Request.SetRequestURI("/fiber_endpoint1")
-	fctx.Request.Header.SetMethod(http.MethodGet)
+func TestToProblemDetails_Without_Request(t *testing.T) {
 
-	// Create Fiber context
-	ctx := app.AcquireCtx(fctx)
-	defer app.ReleaseCtx(ctx)
+	err := endpoint1()
 
-	// Execute the handler
-	handlerErr := fiber_endpoint1(ctx)
-
-	Map[custom_errors.BadRequestError](func() ProblemDetailErr {
+	Map[badRequestError](func() ProblemDetailErr {
 		return &ProblemDetail{
 			Status: http.StatusBadRequest,
 			Title:  "bad-request",
-			Detail: handlerErr.Error(),
 		}
 	})
 
-	p, _ := ResolveProblemDetails(Response(ctx), Request(ctx), handlerErr)
+	p := ToProblemDetails(nil, err)
 
-	assert.Equal(t, http.StatusBadRequest, ctx.Response().StatusCode())
-	assert.Equal(t, handlerErr.Error(), p.GetDetails())
-	assert.Equal(t, "bad-request", p.GetTitle())
-	assert.Equal(t, "https://httpstatuses.io/400", p.GetType())
 	assert.Equal(t, http.StatusBadRequest, p.GetStatus())
+	assert.Equal(t, err.Error(), p.GetDetails())
+	assert.Equal(t, "bad-request", p.GetTitle())
+	assert.Equal(t, "", p.GetInstance())
 }
 
-func TestMap_Custom_Problem_Err_Fiber(t *testing.T) {
-	app := fiber.New()
-
-	app.Get("/fiber_endpoint4", func(c fiber.Ctx) error {
-		return fiber_endpoint4(c)
-	})
-
-	fctx := &fasthttp.RequestCtx{}
-	fctx.Request.SetRequestURI("/fiber_endpoint4")
-	fctx.Request.Header.SetMethod(http.MethodGet)
-
-	ctx := app.AcquireCtx(fctx)
-	defer app.ReleaseCtx(ctx)
-
-	handlerErr := fiber_endpoint4(ctx)
-
-	Map[custom_errors.ConflictError](func() ProblemDetailErr {
-		return &CustomProblemDetailTest{
-			ProblemDetailErr: &ProblemDetail{
-				Status: http.StatusConflict,
-				Title:  "conflict",
-				Detail: handlerErr.Error(),
-			},
-			AdditionalInfo: "some additional info...",
-			Description:    "some description...",
-		}
-	})
-
-	p, _ := ResolveProblemDetails(Response(ctx), Request(ctx), handlerErr)
-	cp := p.(*CustomProblemDetailTest)
-
-	assert.Equal(t, http.StatusConflict, ctx.Response().StatusCode())
-	assert.Equal(t, handlerErr.Error(), cp.GetDetails())
-	assert.Equal(t, "conflict", cp.GetTitle())
-	assert.Equal(t, "https://httpstatuses.io/409", cp.GetType())
-	assert.Equal(t, http.StatusConflict, cp.GetStatus())
-	assert.Equal(t, "some description...", cp.Description)
-	assert.Equal(t, "some additional info...", cp.AdditionalInfo)
-}
-
-func TestMap_Status_Fiber(t *testing.T) {
-	app := fiber.New()
+func TestProblemDetail_Extensions_JSON(t *testing.T) {
 
-	app.Get("/fiber_endpoint2", func(c fiber.Ctx) error {
-		return fiber_endpoint2(c)
-	})
+	p := &ProblemDetail{Status: http.StatusForbidden, Title: "out of credit"}
+	p.Extensions = map[string]interface{}{"balance": 30, "accounts": []string{"/account/12345", "/account/67890"}}
 
-	fctx := &fasthttp.RequestCtx{}
-	fctx.Request.SetRequestURI("/fiber_endpoint2")
-	fctx.Request.Header.SetMethod(http.MethodGet)
+	val, err := json.Marshal(p)
 
-	ctx := app.AcquireCtx(fctx)
-	defer app.ReleaseCtx(ctx)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":403,"title":"out of credit","balance":30,"accounts":["/account/12345","/account/67890"]}`, string(val))
 
-	handlerErr := fiber_endpoint2(ctx)
+	var decoded ProblemDetail
+	err = json.Unmarshal(val, &decoded)
 
-	MapStatus(http.StatusBadGateway, func() ProblemDetailErr {
-		return &ProblemDetail{
-			Status: http.StatusUnauthorized,
-			Title:  "unauthorized",
-			Detail: handlerErr.Error(),
-		}
-	})
-
-	p, _ := ResolveProblemDetails(Response(ctx), Request(ctx), handlerErr)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, decoded.Status)
+	assert.Equal(t, "out of credit", decoded.Title)
+	assert.Equal(t, float64(30), decoded.GetExtensions()["balance"])
+	assert.Equal(t, []interface{}{"/account/12345", "/account/67890"}, decoded.GetExtensions()["accounts"])
 
-	assert.Equal(t, http.StatusUnauthorized, ctx.Response().StatusCode())
-	assert.Equal(t, handlerErr.Error(), p.GetDetails())
-	assert.Equal(t, "unauthorized", p.GetTitle())
-	assert.Equal(t, "https://httpstatuses.io/401", p.GetType())
-	assert.Equal(t, http.StatusUnauthorized, p.GetStatus())
+	var accounts []string
+	assert.True(t, DecodeExtension(&decoded, "accounts", &accounts))
+	assert.Equal(t, []string{"/account/12345", "/account/67890"}, accounts)
+	assert.False(t, DecodeExtension(&decoded, "owner", &accounts))
 }
 
-func TestMap_Unhandled_Err_Fiber(t *testing.T) {
-	app := fiber.New()
+func TestMembers_Round_Trip(t *testing.T) {
 
-	app.Get("/fiber_endpoint3", func(c fiber.Ctx) error {
-		return fiber_endpoint3(c)
-	})
-
-	fctx := &fasthttp.RequestCtx{}
-	fctx.Request.SetRequestURI("/fiber_endpoint3")
-	fctx.Request.Header.SetMethod(http.MethodGet)
+	p := &CustomProblemDetailTest{
+		ProblemDetailErr: (&ProblemDetail{Status: http.StatusForbidden, Title: "out of credit"}).SetExtension("balance", 30),
+		Description:      "some description...",
+	}
 
-	ctx := app.AcquireCtx(fctx)
-	defer app.ReleaseCtx(ctx)
+	members, err := Members(p)
 
-	handlerErr := fiber_endpoint3(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"status": int64(403), "title": "out of credit", "balance": int64(30), "description": "some description..."}, members)
 
-	p, _ := ResolveProblemDetails(Response(ctx), Request(ctx), handlerErr)
+	decoded, err := FromMembers(members)
 
-	assert.Equal(t, http.StatusInternalServerError, ctx.Response().StatusCode())
-	assert.Equal(t, handlerErr.Error(), p.GetDetails())
-	assert.Equal(t, "Internal Server Error", p.GetTitle())
-	assert.Equal(t, "https://httpstatuses.io/500", p.GetType())
-	assert.Equal(t, http.StatusInternalServerError, p.GetStatus())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, decoded.GetStatus())
+	assert.Equal(t, "out of credit", decoded.GetTitle())
+	assert.Equal(t, map[string]interface{}{"balance": float64(30), "description": "some description..."}, decoded.GetExtensions())
 }
 
-func echo_endpoint1(c echo.Context) error {
+func endpoint1() error {
 	err := errors.New("We have a custom type error in our endpoint")
-	return custom_errors.BadRequestError{InternalError: err}
+	return badRequestError{InternalError: err}
 }
 
-func echo_endpoint2(c echo.Context) error {
+func endpoint2() error {
 	err := errors.New("We have a specific status code error in our endpoint")
-	return echo.NewHTTPError(http.StatusBadGateway, err)
-}
-
-func echo_endpoint2b(c echo.Context) error {
-	return echo.NewHTTPError(http.StatusBadGateway, "We have a specific status code error in our endpoint")
+	return statusError{Code: http.StatusBadGateway, InternalError: err}
 }
 
-func echo_endpoint3(c echo.Context) error {
+func endpoint3() error {
 	err := errors.New("We have a unhandeled error in our endpoint")
 	return err
 }
 
-func echo_endpoint4(c echo.Context) error {
+func endpoint4() error {
 	err := errors.New("We have a custom error with custom problem details error in our endpoint")
-	return custom_errors.ConflictError{InternalError: err}
+	return conflictError{InternalError: err}
 }
 
-func fiber_endpoint1(c fiber.Ctx) error {
-	err := errors.New("We have a custom type error in our endpoint")
-	return custom_errors.BadRequestError{InternalError: err}
+type badRequestError struct {
+	InternalError error
 }
 
-func fiber_endpoint2(c fiber.Ctx) error {
-	err := errors.New("We have a specific status code error in our endpoint")
-	return fiber.NewError(http.StatusBadGateway, err.Error())
+type conflictError struct {
+	InternalError error
 }
 
-func fiber_endpoint3(c fiber.Ctx) error {
-	err := errors.New("We have an unhandled error in our endpoint")
-	return err
+type statusError struct {
+	Code          int
+	InternalError error
 }
 
-func fiber_endpoint4(c fiber.Ctx) error {
-	err := errors.New("We have a custom error with custom problem details error in our endpoint")
-	return custom_errors.ConflictError{InternalError: err}
+func (c conflictError) Error() string {
+	return c.InternalError.Error()
+}
+
+func (b badRequestError) Error() string {
+	return b.InternalError.Error()
+}
+
+func (s statusError) Error() string {
+	return s.InternalError.Error()
 }
 
 type CustomProblemDetailTest struct {
@@ -492,3 +266,8 @@ type CustomProblemDetailTest struct {
 	Description    string `json:"description,omitempty"`
 	AdditionalInfo string `json:"additionalInfo,omitempty"`
 }
+
+type orderProblem struct {
+	*ProblemDetail
+	Order string `json:"order,omitempty"`
+}
@@ -0,0 +1,54 @@
+// Package problemcbor encode and decode problem details error with application/problem+cbor media type
+package problemcbor
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/meysamhadeli/problem-details"
+	"io"
+	"reflect"
+)
+
+// ContentType media type of cbor problem details
+const ContentType = "application/problem+cbor"
+
+type encoder struct{}
+
+var encMode, _ = cbor.CoreDetEncOptions().EncMode()
+
+var decMode, _ = cbor.DecOptions{DefaultMapType: reflect.TypeOf(map[string]interface{}(nil))}.DecMode()
+
+// Encoder serialize problem details error with application/problem+cbor media type for content negotiation of the resolver
+func Encoder() problem.Encoder {
+	return encoder{}
+}
+
+func (encoder) ContentType() string {
+	return ContentType
+}
+
+func (encoder) Encode(w io.Writer, p problem.ProblemDetailErr) error {
+	val, err := Marshal(p)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(val)
+	return err
+}
+
+// Marshal serialize problem details error including extension members to cbor
+func Marshal(p problem.ProblemDetailErr) ([]byte, error) {
+	members, err := problem.Members(p)
+	if err != nil {
+		return nil, err
+	}
+	return encMode.Marshal(members)
+}
+
+// Decode deserialize cbor problem details, unknown members are collected as extensions
+func Decode(data []byte) (*problem.ProblemDetail, error) {
+	var members map[string]interface{}
+	if err := decMode.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	return problem.FromMembers(members)
+}
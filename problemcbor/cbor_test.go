@@ -0,0 +1,53 @@
+package problemcbor
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMarshal_Decode(t *testing.T) {
+
+	p := &problem.ProblemDetail{Status: http.StatusForbidden, Title: "out of credit", Detail: "Your current balance is 30, but that costs 50."}
+	p.SetExtension("balance", 30)
+	p.SetExtension("accounts", []string{"/account/12345", "/account/67890"})
+
+	val, err := Marshal(p)
+	assert.NoError(t, err)
+
+	var raw map[string]interface{}
+	assert.NoError(t, cbor.Unmarshal(val, &raw))
+	assert.Equal(t, uint64(403), raw["status"])
+
+	decoded, err := Decode(val)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, decoded.GetStatus())
+	assert.Equal(t, "out of credit", decoded.GetTitle())
+	assert.Equal(t, "Your current balance is 30, but that costs 50.", decoded.GetDetails())
+	assert.Equal(t, float64(30), decoded.GetExtensions()["balance"])
+	assert.Equal(t, []interface{}{"/account/12345", "/account/67890"}, decoded.GetExtensions()["accounts"])
+}
+
+func TestEncoder_Negotiation(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://cbor_endpoint1", nil)
+	req.Header.Set("Accept", ContentType)
+	rec := httptest.NewRecorder()
+
+	resolver := problem.NewResolver(problem.WithEncoders(problem.JSONEncoder(), Encoder()))
+
+	_, _ = resolver.ResolveProblemDetails(rec, req, &problem.ProblemDetail{Status: http.StatusNotFound, Detail: "order 42 not found"})
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+
+	decoded, err := Decode(rec.Body.Bytes())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "order 42 not found", decoded.GetDetails())
+	assert.Equal(t, "/", decoded.GetInstance())
+}
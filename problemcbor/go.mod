@@ -0,0 +1,17 @@
+module github.com/meysamhadeli/problem-details/problemcbor
+
+go 1.23.2
+
+require (
+	github.com/fxamacker/cbor/v2 v2.8.0
+	github.com/meysamhadeli/problem-details v1.5.0
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
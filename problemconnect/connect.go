@@ -0,0 +1,158 @@
+// Package problemconnect convert problem details error to connect error and back, and register connect error unwrapper for resolve problem details error
+package problemconnect
+
+import (
+	"connectrpc.com/connect"
+	"encoding/json"
+	"errors"
+	"github.com/meysamhadeli/problem-details"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"net/http"
+)
+
+// DetailsExtension extension member carrying connect error details
+const DetailsExtension = "details"
+
+// ErrorDetail connect error detail with its fully-qualified protobuf type name and protojson value
+type ErrorDetail struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Unwrapper unwrap connect error to status code, message and problem details error of FromError with its details
+type Unwrapper struct{}
+
+var codeToStatus = map[connect.Code]int{
+	connect.CodeCanceled:           499,
+	connect.CodeUnknown:            http.StatusInternalServerError,
+	connect.CodeInvalidArgument:    http.StatusBadRequest,
+	connect.CodeDeadlineExceeded:   http.StatusGatewayTimeout,
+	connect.CodeNotFound:           http.StatusNotFound,
+	connect.CodeAlreadyExists:      http.StatusConflict,
+	connect.CodePermissionDenied:   http.StatusForbidden,
+	connect.CodeResourceExhausted:  http.StatusTooManyRequests,
+	connect.CodeFailedPrecondition: http.StatusBadRequest,
+	connect.CodeAborted:            http.StatusConflict,
+	connect.CodeOutOfRange:         http.StatusBadRequest,
+	connect.CodeUnimplemented:      http.StatusNotImplemented,
+	connect.CodeInternal:           http.StatusInternalServerError,
+	connect.CodeUnavailable:        http.StatusServiceUnavailable,
+	connect.CodeDataLoss:           http.StatusInternalServerError,
+	connect.CodeUnauthenticated:    http.StatusUnauthorized,
+}
+
+var statusToCode = map[int]connect.Code{
+	http.StatusBadRequest:                   connect.CodeInvalidArgument,
+	http.StatusUnauthorized:                 connect.CodeUnauthenticated,
+	http.StatusForbidden:                    connect.CodePermissionDenied,
+	http.StatusNotFound:                     connect.CodeNotFound,
+	http.StatusRequestTimeout:               connect.CodeDeadlineExceeded,
+	http.StatusConflict:                     connect.CodeAlreadyExists,
+	http.StatusPreconditionFailed:           connect.CodeFailedPrecondition,
+	http.StatusRequestedRangeNotSatisfiable: connect.CodeOutOfRange,
+	http.StatusUnprocessableEntity:          connect.CodeInvalidArgument,
+	http.StatusTooManyRequests:              connect.CodeResourceExhausted,
+	499:                                     connect.CodeCanceled,
+	http.StatusInternalServerError:          connect.CodeInternal,
+	http.StatusNotImplemented:               connect.CodeUnimplemented,
+	http.StatusServiceUnavailable:           connect.CodeUnavailable,
+	http.StatusGatewayTimeout:               connect.CodeDeadlineExceeded,
+}
+
+func init() {
+	problem.RegisterUnwrapper(Unwrapper{})
+}
+
+func (Unwrapper) Unwrap(_ http.ResponseWriter, err error) (int, string, error, bool) {
+	var connectError *connect.Error
+	if !errors.As(err, &connectError) {
+		return 0, "", err, false
+	}
+	p, _ := FromError(connectError)
+	return HTTPStatusFromCode(connectError.Code()), connectError.Message(), p.(error), true
+}
+
+// HTTPStatusFromCode map connect code to http status code
+func HTTPStatusFromCode(code connect.Code) int {
+	if statusCode, ok := codeToStatus[code]; ok {
+		return statusCode
+	}
+	return http.StatusInternalServerError
+}
+
+// CodeFromHTTPStatus map http status code to connect code
+func CodeFromHTTPStatus(statusCode int) connect.Code {
+	if code, ok := statusToCode[statusCode]; ok {
+		return code
+	}
+	if statusCode >= 400 && statusCode < 500 {
+		return connect.CodeFailedPrecondition
+	}
+	if statusCode >= 500 {
+		return connect.CodeInternal
+	}
+	return connect.CodeUnknown
+}
+
+// ToError convert problem details error to connect error, registered protobuf messages of details extension are carried as error details
+func ToError(p problem.ProblemDetailErr) *connect.Error {
+	message := p.GetDetails()
+	if message == "" {
+		message = p.GetTitle()
+	}
+	connectError := connect.NewError(CodeFromHTTPStatus(p.GetStatus()), errors.New(message))
+
+	var details []ErrorDetail
+	if !problem.DecodeExtension(p, DetailsExtension, &details) {
+		return connectError
+	}
+	for _, detail := range details {
+		messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(detail.Type))
+		if err != nil {
+			continue
+		}
+		msg := messageType.New().Interface()
+		if err := protojson.Unmarshal(detail.Value, msg); err != nil {
+			continue
+		}
+		if errorDetail, err := connect.NewErrorDetail(msg); err == nil {
+			connectError.AddDetail(errorDetail)
+		}
+	}
+	return connectError
+}
+
+// FromError convert connect error to problem details error, error details are carried as details extension,
+// ok is false when the error is not a connect error
+func FromError(err error) (problem.ProblemDetailErr, bool) {
+	var connectError *connect.Error
+	if !errors.As(err, &connectError) {
+		return nil, false
+	}
+
+	statusCode := HTTPStatusFromCode(connectError.Code())
+	p := &problem.ProblemDetail{
+		Status: statusCode,
+		Title:  http.StatusText(statusCode),
+		Detail: connectError.Message(),
+	}
+
+	var details []ErrorDetail
+	for _, errorDetail := range connectError.Details() {
+		msg, err := errorDetail.Value()
+		if err != nil {
+			continue
+		}
+		value, err := protojson.Marshal(msg)
+		if err != nil {
+			continue
+		}
+		details = append(details, ErrorDetail{Type: errorDetail.Type(), Value: value})
+	}
+	if len(details) > 0 {
+		p.SetExtension(DetailsExtension, details)
+	}
+	return p, true
+}
@@ -0,0 +1,65 @@
+package problemconnect
+
+import (
+	"connectrpc.com/connect"
+	"errors"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromError_Details(t *testing.T) {
+
+	connectError := connect.NewError(connect.CodeNotFound, errors.New("order 42 not found"))
+	detail, _ := connect.NewErrorDetail(wrapperspb.String("orders.example.com"))
+	connectError.AddDetail(detail)
+
+	p, ok := FromError(connectError)
+
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, p.GetStatus())
+	assert.Equal(t, "Not Found", p.GetTitle())
+	assert.Equal(t, "order 42 not found", p.GetDetails())
+
+	details := problem.Extensions(p)[DetailsExtension].([]ErrorDetail)
+	assert.Equal(t, "google.protobuf.StringValue", details[0].Type)
+	assert.JSONEq(t, `"orders.example.com"`, string(details[0].Value))
+}
+
+func TestToError_Details(t *testing.T) {
+
+	p := &problem.ProblemDetail{Status: http.StatusConflict, Title: "conflict", Detail: "order 42 already exists"}
+	p.SetExtension(DetailsExtension, []map[string]interface{}{{"type": "google.protobuf.StringValue", "value": "orders.example.com"}})
+
+	connectError := ToError(p)
+
+	assert.Equal(t, connect.CodeAlreadyExists, connectError.Code())
+	assert.Equal(t, "order 42 already exists", connectError.Message())
+
+	value, err := connectError.Details()[0].Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "orders.example.com", value.(*wrapperspb.StringValue).GetValue())
+}
+
+func TestResolve_Connect_Error(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://connect_endpoint1", nil)
+	rec := httptest.NewRecorder()
+
+	err := connect.NewError(connect.CodePermissionDenied, errors.New("order 42 belongs to another customer"))
+	detail, _ := connect.NewErrorDetail(wrapperspb.String("orders.example.com"))
+	err.AddDetail(detail)
+
+	p, _ := problem.ResolveProblemDetails(rec, req, err)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, "order 42 belongs to another customer", p.GetDetails())
+	assert.Equal(t, http.StatusForbidden, p.GetStatus())
+
+	details := problem.Extensions(p)[DetailsExtension].([]ErrorDetail)
+	assert.Equal(t, "google.protobuf.StringValue", details[0].Type)
+	assert.Contains(t, rec.Body.String(), `"details":[{"type":"google.protobuf.StringValue","value":"orders.example.com"}]`)
+}
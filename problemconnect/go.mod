@@ -0,0 +1,17 @@
+module github.com/meysamhadeli/problem-details/problemconnect
+
+go 1.23.2
+
+require (
+	connectrpc.com/connect v1.18.1
+	github.com/meysamhadeli/problem-details v1.5.0
+	github.com/stretchr/testify v1.10.0
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
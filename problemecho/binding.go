@@ -0,0 +1,32 @@
+package problemecho
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/meysamhadeli/problem-details"
+	"net/http"
+)
+
+// BindingMappings mappings of the errors of echo binding, e.g. c.Bind and echo.QueryParamsBinder, to 400 and 422
+// problems with the invalid fields, install them with problem.WithMappings
+func BindingMappings() []problem.Mapping {
+	return append(problem.BindingMappings(), problem.Mapping{
+		Name:     "echo.BindingError",
+		Priority: 1,
+		Match:    problem.MatchAs[*echo.BindingError](),
+		Problem: func(err error) problem.ProblemDetailErr {
+			var bindingErr *echo.BindingError
+			errors.As(err, &bindingErr)
+			p := &problem.ProblemDetail{
+				Status: http.StatusBadRequest,
+				Detail: fmt.Sprintf("parameter %q is invalid", bindingErr.Field),
+			}
+			return problem.AttachFieldErrors(p, problem.FieldError{
+				Parameter: bindingErr.Field,
+				Reason:    fmt.Sprint(bindingErr.Message),
+				Code:      "invalid_value",
+			})
+		},
+	})
+}
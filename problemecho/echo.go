@@ -0,0 +1,62 @@
+// Package problemecho register echo error unwrapper for resolve problem details error
+package problemecho
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/meysamhadeli/problem-details"
+	"net/http"
+)
+
+// Unwrapper unwrap echo.HTTPError to status code, public message and internal error
+type Unwrapper struct{}
+
+func init() {
+	problem.RegisterUnwrapper(Unwrapper{})
+}
+
+func (Unwrapper) Unwrap(_ http.ResponseWriter, err error) (int, string, error, bool) {
+	var echoError *echo.HTTPError
+	if !errors.As(err, &echoError) {
+		return 0, "", err, false
+	}
+
+	var errorMsg string
+	if echoErr, ok := echoError.Message.(error); ok {
+		err = echoErr
+	} else if messageStr, ok := echoError.Message.(string); ok {
+		err = errors.New(messageStr)
+	}
+	if echoError.Internal != nil {
+		errorMsg = err.Error()
+		err = echoError.Internal
+	}
+	return echoError.Code, errorMsg, err, true
+}
+
+// Recover recover panics of the handlers and resolve them to problem details error with the resolver,
+// DefaultResolver is used when resolver is nil. The problem isn't written when the response is already committed
+func Recover(resolver *problem.Resolver) echo.MiddlewareFunc {
+	if resolver == nil {
+		resolver = problem.DefaultResolver
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			defer func() {
+				value := recover()
+				if value == nil {
+					return
+				}
+
+				panicErr := problem.NewPanicError(value)
+				if c.Response().Committed {
+					resolver.ToProblemDetails(c.Request(), panicErr)
+					return
+				}
+				_, _ = resolver.ResolveProblemDetails(c.Response(), c.Request(), panicErr)
+			}()
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package problemecho
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMap_CustomType_Echo(t *testing.T) {
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := echo_endpoint1(c)
+
+	problem.Map[badRequestError](func() problem.ProblemDetailErr {
+		return &problem.ProblemDetail{
+			Status: http.StatusBadRequest,
+			Title:  "bad-request",
+			Detail: err.Error(),
+		}
+	})
+
+	p, _ := problem.ResolveProblemDetails(c.Response(), c.Request(), err)
+
+	assert.Equal(t, http.StatusBadRequest, c.Response().Status)
+
+	assert.Equal(t, err.Error(), p.GetDetails())
+	assert.Equal(t, "bad-request", p.GetTitle())
+	assert.Equal(t, problem.AboutBlank, p.GetType())
+	assert.Equal(t, http.StatusBadRequest, p.GetStatus())
+}
+
+func TestRecover_Echo(t *testing.T) {
+
+	e := echo.New()
+	e.Use(Recover(nil))
+
+	e.GET("/echo_endpoint5", func(c echo.Context) error {
+		panic("order is nil")
+	})
+	e.GET("/echo_endpoint6", func(c echo.Context) error {
+		_ = c.String(http.StatusAccepted, "partial")
+		panic("order is nil")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/echo_endpoint5", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"detail":"panic: order is nil"`)
+
+	req = httptest.NewRequest(http.MethodGet, "/echo_endpoint6", nil)
+	rec = httptest.NewRecorder()
+
+	assert.NotPanics(t, func() {
+		e.ServeHTTP(rec, req)
+	})
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "partial", rec.Body.String())
+}
+
+func TestBindingMappings_Echo(t *testing.T) {
+
+	e := echo.New()
+	resolver := problem.NewResolver(problem.WithMappings(BindingMappings()...))
+
+	req := httptest.NewRequest(http.MethodPost, "http://echo_endpoint7?page=first", strings.NewReader(`{"quantity":"two"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var order struct {
+		Quantity int `json:"quantity"`
+	}
+	p, _ := resolver.ResolveProblemDetails(c.Response(), c.Request(), c.Bind(&order))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, p.GetStatus())
+	assert.Equal(t, []problem.FieldError{{Pointer: "/quantity", Location: problem.LocationBody, Reason: "must be int", Code: "invalid_type"}}, problem.Extensions(p)[problem.ErrorsExtension])
+
+	var page int
+	err := echo.QueryParamsBinder(c).Int("page", &page).BindError()
+	p = resolver.ToProblemDetails(c.Request(), err)
+
+	assert.Equal(t, http.StatusBadRequest, p.GetStatus())
+	assert.Equal(t, []problem.FieldError{{Parameter: "page", Reason: "failed to bind field value to int", Code: "invalid_value"}}, problem.Extensions(p)[problem.ErrorsExtension])
+}
+
+func TestMap_Custom_Problem_Err_Echo(t *testing.T) {
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint4", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := echo_endpoint4(c)
+
+	problem.Map[conflictError](func() problem.ProblemDetailErr {
+		return &CustomProblemDetailTest{
+			ProblemDetailErr: &problem.ProblemDetail{
+				Status: http.StatusConflict,
+				Title:  "conflict",
+				Detail: err.Error(),
+			},
+			AdditionalInfo: "some additional info...",
+			Description:    "some description...",
+		}
+	})
+
+	p, _ := problem.ResolveProblemDetails(c.Response(), c.Request(), err)
+	cp := p.(*CustomProblemDetailTest)
+
+	assert.Equal(t, http.StatusConflict, c.Response().Status)
+
+	assert.Equal(t, err.Error(), cp.GetDetails())
+	assert.Equal(t, "conflict", cp.GetTitle())
+	assert.Equal(t, problem.AboutBlank, cp.GetType())
+	assert.Equal(t, http.StatusConflict, cp.GetStatus())
+	assert.Equal(t, "some description...", cp.Description)
+	assert.Equal(t, "some additional info...", cp.AdditionalInfo)
+}
+
+func TestMap_Status_Echo(t *testing.T) {
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := echo_endpoint2(c)
+
+	problem.MapStatus(http.StatusBadGateway, func() problem.ProblemDetailErr {
+		return &problem.ProblemDetail{
+			Status: http.StatusUnauthorized,
+			Title:  "unauthorized",
+			Detail: err.Error(),
+		}
+	})
+
+	p, _ := problem.ResolveProblemDetails(c.Response(), c.Request(), err)
+
+	assert.Equal(t, http.StatusUnauthorized, c.Response().Status)
+
+	assert.Equal(t, "We have a specific status code error in our endpoint", p.GetDetails())
+	assert.Equal(t, "unauthorized", p.GetTitle())
+	assert.Equal(t, problem.AboutBlank, p.GetType())
+	assert.Equal(t, http.StatusUnauthorized, p.GetStatus())
+}
+
+func TestMap_Status2_Echo(t *testing.T) {
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := echo_endpoint2b(c)
+
+	problem.MapStatus(http.StatusBadGateway, func() problem.ProblemDetailErr {
+		return &problem.ProblemDetail{
+			Status: http.StatusUnauthorized,
+			Title:  "unauthorized",
+			Detail: err.Error(),
+		}
+	})
+
+	p, _ := problem.ResolveProblemDetails(c.Response(), c.Request(), err)
+
+	assert.Equal(t, c.Response().Status, http.StatusUnauthorized)
+	assert.Equal(t, "We have a specific status code error in our endpoint", p.GetDetails())
+	assert.Equal(t, "unauthorized", p.GetTitle())
+	assert.Equal(t, problem.AboutBlank, p.GetType())
+	assert.Equal(t, http.StatusUnauthorized, p.GetStatus())
+}
+
+func TestMap_Status_Details_Echo(t *testing.T) {
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := echo.HTTPError{Code: http.StatusNotFound, Message: "Entity not found. Please contact admin.", Internal: errors.New("Error with additional analysis/audit logging information")}
+
+	problem.MapStatus(http.StatusBadGateway, func() problem.ProblemDetailErr {
+		return &problem.ProblemDetail{
+			Status: http.StatusNotFound,
+			Title:  "Not Found",
+			Detail: err.Error(),
+		}
+	})
+
+	p, _ := problem.ResolveProblemDetails(c.Response(), c.Request(), &err)
+
+	assert.Equal(t, http.StatusNotFound, c.Response().Status)
+	assert.Equal(t, "Entity not found. Please contact admin.", p.GetDetails())
+	assert.Equal(t, "Not Found", p.GetTitle())
+	assert.Equal(t, problem.AboutBlank, p.GetType())
+	assert.Equal(t, http.StatusNotFound, p.GetStatus())
+}
+
+func TestMap_Unhandled_Err_Echo(t *testing.T) {
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "http://echo_endpoint3", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := echo_endpoint3(c)
+
+	p, _ := problem.ResolveProblemDetails(c.Response(), c.Request(), err)
+
+	assert.Equal(t, http.StatusInternalServerError, c.Response().Status)
+
+	assert.Equal(t, err.Error(), p.GetDetails())
+	assert.Equal(t, "Internal Server Error", p.GetTitle())
+	assert.Equal(t, problem.AboutBlank, p.GetType())
+	assert.Equal(t, http.StatusInternalServerError, p.GetStatus())
+}
+
+func echo_endpoint1(c echo.Context) error {
+	err := errors.New("We have a custom type error in our endpoint")
+	return badRequestError{InternalError: err}
+}
+
+func echo_endpoint2(c echo.Context) error {
+	err := errors.New("We have a specific status code error in our endpoint")
+	return echo.NewHTTPError(http.StatusBadGateway, err)
+}
+
+func echo_endpoint2b(c echo.Context) error {
+	return echo.NewHTTPError(http.StatusBadGateway, "We have a specific status code error in our endpoint")
+}
+
+func echo_endpoint3(c echo.Context) error {
+	err := errors.New("We have a unhandeled error in our endpoint")
+	return err
+}
+
+func echo_endpoint4(c echo.Context) error {
+	err := errors.New("We have a custom error with custom problem details error in our endpoint")
+	return conflictError{InternalError: err}
+}
+
+type badRequestError struct {
+	InternalError error
+}
+
+type conflictError struct {
+	InternalError error
+}
+
+func (c conflictError) Error() string {
+	return c.InternalError.Error()
+}
+
+func (b badRequestError) Error() string {
+	return b.InternalError.Error()
+}
+
+type CustomProblemDetailTest struct {
+	problem.ProblemDetailErr
+	Description    string `json:"description,omitempty"`
+	AdditionalInfo string `json:"additionalInfo,omitempty"`
+}
@@ -0,0 +1,56 @@
+package problemfiber
+
+import (
+	"fmt"
+	"github.com/gofiber/schema"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/pkg/errors"
+	"net/http"
+	"slices"
+)
+
+// BindingMappings mappings of the errors of fiber binding, e.g. c.Bind().WithoutAutoHandling().Query, to 400 and 422
+// problems with the invalid fields, install them with problem.WithMappings. With auto handling fiber replaces
+// binding errors with fiber.Error which has no invalid fields
+func BindingMappings() []problem.Mapping {
+	return append(problem.BindingMappings(), problem.Mapping{
+		Name:     "schema.MultiError",
+		Priority: 1,
+		Match:    problem.MatchAs[schema.MultiError](),
+		Problem: func(err error) problem.ProblemDetailErr {
+			var multiErr schema.MultiError
+			errors.As(err, &multiErr)
+
+			keys := make([]string, 0, len(multiErr))
+			for key := range multiErr {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+
+			p := &problem.ProblemDetail{
+				Status: http.StatusBadRequest,
+				Detail: fmt.Sprintf("%d parameters are invalid", len(keys)),
+			}
+			for _, key := range keys {
+				reason, code := schemaErrorReason(multiErr[key])
+				problem.AttachFieldErrors(p, problem.FieldError{Parameter: key, Reason: reason, Code: code})
+			}
+			return p
+		},
+	})
+}
+
+func schemaErrorReason(err error) (string, string) {
+	var conversionErr schema.ConversionError
+	var emptyFieldErr schema.EmptyFieldError
+	var unknownKeyErr schema.UnknownKeyError
+	switch {
+	case errors.As(err, &conversionErr):
+		return fmt.Sprintf("must be %s", conversionErr.Type), "invalid_type"
+	case errors.As(err, &emptyFieldErr):
+		return "is required", "required"
+	case errors.As(err, &unknownKeyErr):
+		return "is unknown", "unknown_field"
+	}
+	return err.Error(), "invalid_value"
+}
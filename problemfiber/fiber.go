@@ -0,0 +1,100 @@
+// Package problemfiber register fiber error unwrapper and adapt fiber context to net/http for resolve problem details error
+package problemfiber
+
+import (
+	"github.com/gofiber/fiber/v3"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+)
+
+type fiberResponseWriter struct {
+	ctx     fiber.Ctx
+	written bool
+	headers http.Header
+}
+
+// Unwrapper unwrap fiber.Error to status code and message
+type Unwrapper struct{}
+
+func init() {
+	problem.RegisterUnwrapper(Unwrapper{})
+}
+
+func (Unwrapper) Unwrap(_ http.ResponseWriter, err error) (int, string, error, bool) {
+	var fiberError *fiber.Error
+	if !errors.As(err, &fiberError) {
+		return 0, "", err, false
+	}
+	return fiberError.Code, "", errors.New(fiberError.Message), true
+}
+
+// Response adapt fiber context to http.ResponseWriter
+func Response(c fiber.Ctx) http.ResponseWriter {
+	return &fiberResponseWriter{
+		ctx:     c,
+		headers: make(http.Header),
+	}
+}
+
+func (f *fiberResponseWriter) Header() http.Header {
+	return f.headers
+}
+
+func (f *fiberResponseWriter) Write(data []byte) (int, error) {
+	f.written = true
+	return f.ctx.Response().BodyWriter().Write(data)
+}
+
+func (f *fiberResponseWriter) WriteHeader(statusCode int) {
+	f.written = true
+	for k := range f.headers {
+		f.ctx.Set(k, f.headers.Get(k))
+	}
+	f.ctx.Status(statusCode)
+}
+
+// Request adapt fiber context to http.Request
+func Request(c fiber.Ctx) *http.Request {
+	fiberURI := c.Request().URI()
+	parsedURL, _ := url.Parse(string(fiberURI.FullURI()))
+
+	header := make(http.Header)
+	c.Request().Header.VisitAll(func(key, value []byte) {
+		header.Add(string(key), string(value))
+	})
+
+	return &http.Request{
+		Method:     c.Method(),
+		URL:        parsedURL,
+		Header:     header,
+		RequestURI: string(c.Request().RequestURI()),
+	}
+}
+
+// Recover recover panics of the handlers and resolve them to problem details error with the resolver,
+// DefaultResolver is used when resolver is nil. The problem isn't written when the response is already streamed
+func Recover(resolver *problem.Resolver) fiber.Handler {
+	if resolver == nil {
+		resolver = problem.DefaultResolver
+	}
+	return func(c fiber.Ctx) error {
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+
+			panicErr := problem.NewPanicError(value)
+			if c.Response().IsBodyStream() {
+				resolver.ToProblemDetails(Request(c), panicErr)
+				return
+			}
+			c.Response().ResetBody()
+			_, _ = resolver.ResolveProblemDetails(Response(c), Request(c), panicErr)
+		}()
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,252 @@
+package problemfiber
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v3"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMap_CustomType_Fiber(t *testing.T) {
+	app := fiber.New()
+
+	app.Get("/fiber_endpoint1", func(c fiber.Ctx) error {
+		return fiber_endpoint1(c)
+	})
+
+	// Create fasthttp request context
+	fctx := &fasthttp.RequestCtx{}
+	fctx.Request.SetRequestURI("/fiber_endpoint1")
+	fctx.Request.Header.SetMethod(http.MethodGet)
+
+	// Create Fiber context
+	ctx := app.AcquireCtx(fctx)
+	defer app.ReleaseCtx(ctx)
+
+	// Execute the handler
+	handlerErr := fiber_endpoint1(ctx)
+
+	problem.Map[badRequestError](func() problem.ProblemDetailErr {
+		return &problem.ProblemDetail{
+			Status: http.StatusBadRequest,
+			Title:  "bad-request",
+			Detail: handlerErr.Error(),
+		}
+	})
+
+	p, _ := problem.ResolveProblemDetails(Response(ctx), Request(ctx), handlerErr)
+
+	assert.Equal(t, http.StatusBadRequest, ctx.Response().StatusCode())
+	assert.Equal(t, handlerErr.Error(), p.GetDetails())
+	assert.Equal(t, "bad-request", p.GetTitle())
+	assert.Equal(t, problem.AboutBlank, p.GetType())
+	assert.Equal(t, http.StatusBadRequest, p.GetStatus())
+}
+
+func TestRecover_Fiber(t *testing.T) {
+	app := fiber.New()
+	app.Use(Recover(nil))
+
+	app.Get("/fiber_endpoint5", func(c fiber.Ctx) error {
+		_ = c.SendString("partial")
+		panic("order is nil")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/fiber_endpoint5", nil))
+	assert.NoError(t, err)
+
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(body), `"detail":"panic: order is nil"`)
+	assert.NotContains(t, string(body), "partial")
+}
+
+func TestBindingMappings_Fiber(t *testing.T) {
+	app := fiber.New()
+	resolver := problem.NewResolver(problem.WithMappings(BindingMappings()...))
+
+	app.Get("/fiber_endpoint7", func(c fiber.Ctx) error {
+		var query struct {
+			Page  int `query:"page"`
+			Limit int `query:"limit"`
+		}
+		if err := c.Bind().WithoutAutoHandling().Query(&query); err != nil {
+			_, _ = resolver.ResolveProblemDetails(Response(c), Request(c), err)
+		}
+		return nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/fiber_endpoint7?page=first&limit=ten", nil))
+	assert.NoError(t, err)
+
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, string(body), `"errors":[{"parameter":"limit","detail":"must be int","code":"invalid_type"},{"parameter":"page","detail":"must be int","code":"invalid_type"}]`)
+}
+
+func TestMap_Custom_Problem_Err_Fiber(t *testing.T) {
+	app := fiber.New()
+
+	app.Get("/fiber_endpoint4", func(c fiber.Ctx) error {
+		return fiber_endpoint4(c)
+	})
+
+	fctx := &fasthttp.RequestCtx{}
+	fctx.Request.SetRequestURI("/fiber_endpoint4")
+	fctx.Request.Header.SetMethod(http.MethodGet)
+
+	ctx := app.AcquireCtx(fctx)
+	defer app.ReleaseCtx(ctx)
+
+	handlerErr := fiber_endpoint4(ctx)
+
+	problem.Map[conflictError](func() problem.ProblemDetailErr {
+		return &CustomProblemDetailTest{
+			ProblemDetailErr: &problem.ProblemDetail{
+				Status: http.StatusConflict,
+				Title:  "conflict",
+				Detail: handlerErr.Error(),
+			},
+			AdditionalInfo: "some additional info...",
+			Description:    "some description...",
+		}
+	})
+
+	p, _ := problem.ResolveProblemDetails(Response(ctx), Request(ctx), handlerErr)
+	cp := p.(*CustomProblemDetailTest)
+
+	assert.Equal(t, http.StatusConflict, ctx.Response().StatusCode())
+	assert.Equal(t, handlerErr.Error(), cp.GetDetails())
+	assert.Equal(t, "conflict", cp.GetTitle())
+	assert.Equal(t, problem.AboutBlank, cp.GetType())
+	assert.Equal(t, http.StatusConflict, cp.GetStatus())
+	assert.Equal(t, "some description...", cp.Description)
+	assert.Equal(t, "some additional info...", cp.AdditionalInfo)
+}
+
+func TestMap_Status_Fiber(t *testing.T) {
+	app := fiber.New()
+
+	app.Get("/fiber_endpoint2", func(c fiber.Ctx) error {
+		return fiber_endpoint2(c)
+	})
+
+	fctx := &fasthttp.RequestCtx{}
+	fctx.Request.SetRequestURI("/fiber_endpoint2")
+	fctx.Request.Header.SetMethod(http.MethodGet)
+
+	ctx := app.AcquireCtx(fctx)
+	defer app.ReleaseCtx(ctx)
+
+	handlerErr := fiber_endpoint2(ctx)
+
+	problem.MapStatus(http.StatusBadGateway, func() problem.ProblemDetailErr {
+		return &problem.ProblemDetail{
+			Status: http.StatusUnauthorized,
+			Title:  "unauthorized",
+			Detail: handlerErr.Error(),
+		}
+	})
+
+	p, _ := problem.ResolveProblemDetails(Response(ctx), Request(ctx), handlerErr)
+
+	assert.Equal(t, http.StatusUnauthorized, ctx.Response().StatusCode())
+	assert.Equal(t, handlerErr.Error(), p.GetDetails())
+	assert.Equal(t, "unauthorized", p.GetTitle())
+	assert.Equal(t, problem.AboutBlank, p.GetType())
+	assert.Equal(t, http.StatusUnauthorized, p.GetStatus())
+}
+
+func TestMap_Unhandled_Err_Fiber(t *testing.T) {
+	app := fiber.New()
+
+	app.Get("/fiber_endpoint3", func(c fiber.Ctx) error {
+		return fiber_endpoint3(c)
+	})
+
+	fctx := &fasthttp.RequestCtx{}
+	fctx.Request.SetRequestURI("/fiber_endpoint3")
+	fctx.Request.Header.SetMethod(http.MethodGet)
+
+	ctx := app.AcquireCtx(fctx)
+	defer app.ReleaseCtx(ctx)
+
+	handlerErr := fiber_endpoint3(ctx)
+
+	p, _ := problem.ResolveProblemDetails(Response(ctx), Request(ctx), handlerErr)
+
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().StatusCode())
+	assert.Equal(t, handlerErr.Error(), p.GetDetails())
+	assert.Equal(t, "Internal Server Error", p.GetTitle())
+	assert.Equal(t, problem.AboutBlank, p.GetType())
+	assert.Equal(t, http.StatusInternalServerError, p.GetStatus())
+}
+
+func TestNegotiate_Accept_Fiber(t *testing.T) {
+	app := fiber.New()
+
+	fctx := &fasthttp.RequestCtx{}
+	fctx.Request.SetRequestURI("/fiber_endpoint3")
+	fctx.Request.Header.SetMethod(http.MethodGet)
+	fctx.Request.Header.Set("Accept", "application/problem+xml")
+
+	ctx := app.AcquireCtx(fctx)
+	defer app.ReleaseCtx(ctx)
+
+	handlerErr := fiber_endpoint3(ctx)
+
+	_, _ = problem.ResolveProblemDetails(Response(ctx), Request(ctx), handlerErr)
+
+	assert.Equal(t, http.StatusInternalServerError, ctx.Response().StatusCode())
+	assert.Equal(t, "application/problem+xml", string(ctx.Response().Header.ContentType()))
+}
+
+func fiber_endpoint1(c fiber.Ctx) error {
+	err := errors.New("We have a custom type error in our endpoint")
+	return badRequestError{InternalError: err}
+}
+
+func fiber_endpoint2(c fiber.Ctx) error {
+	err := errors.New("We have a specific status code error in our endpoint")
+	return fiber.NewError(http.StatusBadGateway, err.Error())
+}
+
+func fiber_endpoint3(c fiber.Ctx) error {
+	err := errors.New("We have an unhandled error in our endpoint")
+	return err
+}
+
+func fiber_endpoint4(c fiber.Ctx) error {
+	err := errors.New("We have a custom error with custom problem details error in our endpoint")
+	return conflictError{InternalError: err}
+}
+
+type badRequestError struct {
+	InternalError error
+}
+
+type conflictError struct {
+	InternalError error
+}
+
+func (c conflictError) Error() string {
+	return c.InternalError.Error()
+}
+
+func (b badRequestError) Error() string {
+	return b.InternalError.Error()
+}
+
+type CustomProblemDetailTest struct {
+	problem.ProblemDetailErr
+	Description    string `json:"description,omitempty"`
+	AdditionalInfo string `json:"additionalInfo,omitempty"`
+}
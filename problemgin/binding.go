@@ -0,0 +1,80 @@
+package problemgin
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/meysamhadeli/problem-details"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// BindingMappings mappings of the errors of gin binding, e.g. c.ShouldBindQuery wrapped with NewBindingError and c.Bind,
+// to 400 and 422 problems with the invalid fields, install them with problem.WithMappings. Binding errors without a
+// mapping resolve to 400 as c.Bind writes them
+func BindingMappings() []problem.Mapping {
+	return append(problem.BindingMappings(), problem.Mapping{
+		Name:     "gin.ErrorTypeBind",
+		Priority: -1,
+		Match: problem.MatchBinding(func(error) bool {
+			return true
+		}),
+		Problem: func(err error) problem.ProblemDetailErr {
+			return &problem.ProblemDetail{Status: http.StatusBadRequest}
+		},
+	})
+}
+
+// NewBindingError wrap error of gin binding in problem.BindingError with the query, path or form parameter whose
+// value failed, gin doesn't name the parameter of strconv and time errors, so it is found by its value
+func NewBindingError(c *gin.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	bindingErr := &problem.BindingError{Err: err}
+
+	var value string
+	var numErr *strconv.NumError
+	var parseErr *time.ParseError
+	switch {
+	case errors.As(err, &numErr):
+		value = numErr.Num
+	case errors.As(err, &parseErr):
+		value = parseErr.Value
+	default:
+		return bindingErr
+	}
+
+	if name, ok := parameterOf(c.Request.URL.Query(), value); ok {
+		bindingErr.Parameter, bindingErr.Location = name, problem.LocationQuery
+		return bindingErr
+	}
+	for _, param := range c.Params {
+		if param.Value == value {
+			bindingErr.Parameter, bindingErr.Location = param.Key, problem.LocationPath
+			return bindingErr
+		}
+	}
+	if name, ok := parameterOf(c.Request.PostForm, value); ok {
+		bindingErr.Parameter, bindingErr.Location = name, problem.LocationBody
+	}
+	return bindingErr
+}
+
+// parameterOf first parameter by name which has the value
+func parameterOf(values url.Values, value string) (string, bool) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if slices.Contains(values[name], value) {
+			return name, true
+		}
+	}
+	return "", false
+}
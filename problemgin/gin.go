@@ -0,0 +1,66 @@
+// Package problemgin register gin error unwrapper for resolve problem details error
+package problemgin
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/meysamhadeli/problem-details"
+	"net/http"
+	"net/http/httptest"
+)
+
+// Unwrapper unwrap gin.Error to status code already written on gin response and inner error, errors of gin binding
+// with gin.ErrorTypeBind, e.g. of c.Bind, are wrapped in problem.BindingError for problem.BindingMappings
+type Unwrapper struct{}
+
+func init() {
+	problem.RegisterUnwrapper(Unwrapper{})
+}
+
+func (Unwrapper) Unwrap(w http.ResponseWriter, err error) (int, string, error, bool) {
+	var ginError *gin.Error
+	if !errors.As(err, &ginError) {
+		return 0, "", err, false
+	}
+
+	var statusCode int
+	if rw, ok := w.(gin.ResponseWriter); ok && rw.Written() {
+		statusCode = rw.Status()
+	}
+	if gin.Mode() == gin.TestMode {
+		if rw, ok := w.(*httptest.ResponseRecorder); ok && rw.Code != http.StatusOK {
+			statusCode = rw.Code
+		}
+	}
+	var bindingErr *problem.BindingError
+	if ginError.IsType(gin.ErrorTypeBind) && !errors.As(ginError.Err, &bindingErr) {
+		return statusCode, "", problem.NewBindingError(ginError.Err), true
+	}
+	return statusCode, "", ginError.Err, true
+}
+
+// Recover recover panics of the handlers and resolve them to problem details error with the resolver,
+// DefaultResolver is used when resolver is nil. The problem isn't written when the response is already committed
+func Recover(resolver *problem.Resolver) gin.HandlerFunc {
+	if resolver == nil {
+		resolver = problem.DefaultResolver
+	}
+	return func(c *gin.Context) {
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+
+			panicErr := problem.NewPanicError(value)
+			c.Abort()
+			if c.Writer.Written() {
+				resolver.ToProblemDetails(c.Request, panicErr)
+				return
+			}
+			_, _ = resolver.ResolveProblemDetails(c.Writer, c.Request, panicErr)
+		}()
+
+		c.Next()
+	}
+}
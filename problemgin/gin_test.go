@@ -0,0 +1,265 @@
+package problemgin
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMap_CustomType_Gin(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	r := gin.Default()
+
+	r.GET("/gin_endpoint1", func(ctx *gin.Context) {
+		err := errors.New("We have a custom type error in our endpoint")
+		customBadRequestError := badRequestError{InternalError: err}
+		_ = c.Error(customBadRequestError)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/gin_endpoint1", nil)
+	r.ServeHTTP(w, req)
+
+	for _, err := range c.Errors {
+
+		problem.Map[badRequestError](func() problem.ProblemDetailErr {
+			return &problem.ProblemDetail{
+				Status: http.StatusBadRequest,
+				Title:  "bad-request",
+				Detail: err.Error(),
+			}
+		})
+
+		p, _ := problem.ResolveProblemDetails(w, req, err)
+
+		assert.Equal(t, http.StatusBadRequest, p.GetStatus())
+		assert.Equal(t, err.Error(), p.GetDetails())
+		assert.Equal(t, "bad-request", p.GetTitle())
+		assert.Equal(t, problem.AboutBlank, p.GetType())
+	}
+}
+
+func TestRecover_Gin(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.New()
+	r.Use(Recover(nil))
+
+	r.GET("/gin_endpoint5", func(ctx *gin.Context) {
+		panic("order is nil")
+	})
+	r.GET("/gin_endpoint6", func(ctx *gin.Context) {
+		ctx.String(http.StatusAccepted, "partial")
+		panic("order is nil")
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/gin_endpoint5", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"detail":"panic: order is nil"`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/gin_endpoint6", nil)
+
+	assert.NotPanics(t, func() {
+		r.ServeHTTP(w, req)
+	})
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+}
+
+func TestBindingMappings_Gin(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	r := gin.New()
+	resolver := problem.NewResolver(problem.WithMappings(BindingMappings()...))
+
+	r.POST("/gin_endpoint7", func(ctx *gin.Context) {
+		var order struct {
+			Quantity int `json:"quantity"`
+		}
+		if err := ctx.ShouldBindJSON(&order); err != nil {
+			_, _ = resolver.ResolveProblemDetails(ctx.Writer, ctx.Request, ctx.Error(err))
+		}
+	})
+	r.GET("/gin_endpoint7", func(ctx *gin.Context) {
+		var query struct {
+			Page int `form:"page"`
+		}
+		if err := ctx.ShouldBindQuery(&query); err != nil {
+			_, _ = resolver.ResolveProblemDetails(ctx.Writer, ctx.Request, NewBindingError(ctx, err))
+		}
+	})
+	r.PUT("/gin_endpoint7", func(ctx *gin.Context) {
+		var order struct {
+			Quantity int `json:"quantity"`
+		}
+		if err := ctx.Bind(&order); err != nil {
+			_, _ = resolver.ResolveProblemDetails(ctx.Writer, ctx.Request, ctx.Errors.Last())
+		}
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/gin_endpoint7", strings.NewReader(`{"quantity":"two"}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `"errors":[{"pointer":"/quantity","location":"body","detail":"must be int","code":"invalid_type"}]`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/gin_endpoint7?page=first", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"errors":[{"parameter":"page","location":"query","detail":"is invalid: invalid syntax","code":"invalid_value"}]`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPut, "/gin_endpoint7", strings.NewReader(``))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"detail":"request body is empty or truncated"`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPut, "/gin_endpoint7", strings.NewReader(`<order>`))
+	req.Header.Set("Content-Type", "application/xml")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"Bad Request"`)
+}
+
+func TestMap_Custom_Problem_Err_Gin(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	r := gin.Default()
+
+	r.GET("/gin_endpoint4", func(ctx *gin.Context) {
+		err := errors.New("We have a custom error with custom problem details error in our endpoint")
+		customConflictError := conflictError{InternalError: err}
+		_ = c.Error(customConflictError)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/gin_endpoint4", nil)
+	r.ServeHTTP(w, req)
+
+	for _, err := range c.Errors {
+
+		problem.Map[conflictError](func() problem.ProblemDetailErr {
+			return &CustomProblemDetailTest{
+				ProblemDetailErr: &problem.ProblemDetail{
+					Status: http.StatusConflict,
+					Title:  "conflict",
+					Detail: err.Error(),
+				},
+				AdditionalInfo: "some additional info...",
+				Description:    "some description...",
+			}
+		})
+
+		p, _ := problem.ResolveProblemDetails(w, req, err)
+		cp := p.(*CustomProblemDetailTest)
+
+		assert.Equal(t, http.StatusConflict, cp.GetStatus())
+		assert.Equal(t, err.Error(), cp.GetDetails())
+		assert.Equal(t, "conflict", cp.GetTitle())
+		assert.Equal(t, problem.AboutBlank, cp.GetType())
+		assert.Equal(t, "some description...", cp.Description)
+		assert.Equal(t, "some additional info...", cp.AdditionalInfo)
+	}
+}
+
+func TestMap_Status_Gin(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	r := gin.Default()
+
+	r.GET("/gin_endpoint2", func(ctx *gin.Context) {
+		err := errors.New("We have a specific status code error in our endpoint")
+		_ = c.AbortWithError(http.StatusBadGateway, err)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/gin_endpoint2", nil)
+	r.ServeHTTP(w, req)
+
+	for _, err := range c.Errors {
+
+		problem.MapStatus(http.StatusBadGateway, func() problem.ProblemDetailErr {
+			return &problem.ProblemDetail{
+				Status: http.StatusUnauthorized,
+				Title:  "unauthorized",
+				Detail: err.Error(),
+			}
+		})
+
+		p, _ := problem.ResolveProblemDetails(w, req, err)
+
+		assert.Equal(t, http.StatusUnauthorized, p.GetStatus())
+		assert.Equal(t, err.Error(), p.GetDetails())
+		assert.Equal(t, "unauthorized", p.GetTitle())
+		assert.Equal(t, problem.AboutBlank, p.GetType())
+	}
+}
+
+func TestMap_Unhandled_Err_Gin(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	r := gin.Default()
+
+	r.GET("/gin_endpoint3", func(ctx *gin.Context) {
+		err := errors.New("We have a unhandeled error in our endpoint")
+		_ = c.Error(err)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/gin_endpoint3", nil)
+	r.ServeHTTP(w, req)
+
+	for _, err := range c.Errors {
+
+		p, _ := problem.ResolveProblemDetails(w, req, err)
+
+		assert.Equal(t, http.StatusInternalServerError, p.GetStatus())
+		assert.Equal(t, err.Error(), p.GetDetails())
+		assert.Equal(t, "Internal Server Error", p.GetTitle())
+		assert.Equal(t, problem.AboutBlank, p.GetType())
+	}
+}
+
+type badRequestError struct {
+	InternalError error
+}
+
+type conflictError struct {
+	InternalError error
+}
+
+func (c conflictError) Error() string {
+	return c.InternalError.Error()
+}
+
+func (b badRequestError) Error() string {
+	return b.InternalError.Error()
+}
+
+type CustomProblemDetailTest struct {
+	problem.ProblemDetailErr
+	Description    string `json:"description,omitempty"`
+	AdditionalInfo string `json:"additionalInfo,omitempty"`
+}
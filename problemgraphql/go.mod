@@ -0,0 +1,19 @@
+module github.com/meysamhadeli/problem-details/problemgraphql
+
+go 1.23.2
+
+require (
+	github.com/99designs/gqlgen v0.17.55
+	github.com/meysamhadeli/problem-details v1.5.0
+	github.com/stretchr/testify v1.10.0
+	github.com/vektah/gqlparser/v2 v2.5.17
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/sosodev/duration v1.3.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,74 @@
+// Package problemgraphql present errors of gqlgen server as graphql errors carrying problem details members in extensions
+package problemgraphql
+
+import (
+	"context"
+	"errors"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"net/http"
+)
+
+type requestKey struct{}
+
+// Middleware attach http request to the context for resolve instance of problem details error in ErrorPresenter
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestKey{}, r)))
+	})
+}
+
+// ErrorPresenter resolve error through the registered problem details mappings and present it as graphql error,
+// problem members (type, status, title, detail, instance) and extension members are carried in extensions
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	return ErrorPresenterFor(nil)(ctx, err)
+}
+
+// ErrorPresenterFor return ErrorPresenter resolving errors with the resolver, DefaultResolver is used when resolver
+// is nil
+func ErrorPresenterFor(resolver *problem.Resolver) graphql.ErrorPresenterFunc {
+	if resolver == nil {
+		resolver = problem.DefaultResolver
+	}
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		return present(ctx, resolver, err)
+	}
+}
+
+func present(ctx context.Context, resolver *problem.Resolver, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var cause = err
+	var parserErr *gqlerror.Error
+	if errors.As(err, &parserErr) {
+		if parserErr.Err == nil {
+			return gqlErr
+		}
+		cause = parserErr.Err
+	}
+
+	r, _ := ctx.Value(requestKey{}).(*http.Request)
+	p := resolver.ToProblemDetails(r, cause)
+
+	gqlErr.Message = p.GetDetails()
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	for k, v := range problem.Extensions(p) {
+		gqlErr.Extensions[k] = v
+	}
+	setExtension(gqlErr, "type", p.GetType())
+	setExtension(gqlErr, "status", p.GetStatus())
+	setExtension(gqlErr, "title", p.GetTitle())
+	setExtension(gqlErr, "detail", p.GetDetails())
+	setExtension(gqlErr, "instance", p.GetInstance())
+	return gqlErr
+}
+
+func setExtension(gqlErr *gqlerror.Error, name string, value interface{}) {
+	if value == "" || value == 0 {
+		return
+	}
+	gqlErr.Extensions[name] = value
+}
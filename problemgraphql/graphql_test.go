@@ -0,0 +1,87 @@
+package problemgraphql
+
+import (
+	"context"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorPresenter_Map_CustomType(t *testing.T) {
+
+	problem.Map[notFoundError](func() problem.ProblemDetailErr {
+		return &problem.ProblemDetail{
+			Status: http.StatusNotFound,
+			Title:  "order-not-found",
+			Type:   "https://errors.example.com/order-not-found",
+		}
+	})
+
+	ctx := graphql.WithPathContext(context.Background(), graphql.NewPathWithField("order"))
+	err := notFoundError{ID: "42"}
+
+	gqlErr := ErrorPresenter(ctx, err)
+
+	assert.Equal(t, "order 42 not found", gqlErr.Message)
+	assert.Equal(t, ast.Path{ast.PathName("order")}, gqlErr.Path)
+	assert.Equal(t, http.StatusNotFound, gqlErr.Extensions["status"])
+	assert.Equal(t, "order-not-found", gqlErr.Extensions["title"])
+	assert.Equal(t, "order 42 not found", gqlErr.Extensions["detail"])
+	assert.Equal(t, "https://errors.example.com/order-not-found", gqlErr.Extensions["type"])
+}
+
+func TestErrorPresenter_Instance_And_Extensions(t *testing.T) {
+
+	var gqlErr *gqlerror.Error
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := &problem.ProblemDetail{Status: http.StatusForbidden, Detail: "out of credit"}
+		p.SetExtension("balance", 30)
+		gqlErr = ErrorPresenter(r.Context(), p)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "http://graphql_endpoint1/query", nil))
+
+	assert.Equal(t, "out of credit", gqlErr.Message)
+	assert.Equal(t, http.StatusForbidden, gqlErr.Extensions["status"])
+	assert.Equal(t, "/query", gqlErr.Extensions["instance"])
+	assert.Equal(t, 30, gqlErr.Extensions["balance"])
+}
+
+func TestErrorPresenterFor_Resolver(t *testing.T) {
+
+	resolver := problem.NewResolver(problem.WithMode(problem.ProductionMode))
+	err := &problem.ProblemDetail{Status: http.StatusServiceUnavailable, Detail: "connection refused to 10.0.0.7"}
+
+	gqlErr := ErrorPresenterFor(resolver)(context.Background(), err)
+
+	assert.Equal(t, problem.GenericDetail, gqlErr.Message)
+	assert.Equal(t, http.StatusServiceUnavailable, gqlErr.Extensions["status"])
+	assert.Equal(t, problem.GenericDetail, gqlErr.Extensions["detail"])
+
+	gqlErr = ErrorPresenterFor(nil)(context.Background(), err)
+
+	assert.Equal(t, "connection refused to 10.0.0.7", gqlErr.Message)
+}
+
+func TestErrorPresenter_Parser_Error(t *testing.T) {
+
+	err := gqlerror.Errorf("Cannot query field \"total\" on type \"Order\".")
+
+	gqlErr := ErrorPresenter(context.Background(), err)
+
+	assert.Equal(t, err, gqlErr)
+	assert.Nil(t, gqlErr.Extensions)
+}
+
+type notFoundError struct {
+	ID string
+}
+
+func (n notFoundError) Error() string {
+	return "order " + n.ID + " not found"
+}
@@ -0,0 +1,216 @@
+// Package problemgrpc convert problem details error to gRPC status and back, and register gRPC status unwrapper for resolve problem details error
+package problemgrpc
+
+import (
+	"context"
+	"errors"
+	"github.com/meysamhadeli/problem-details"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// RetryDelayExtension extension member carrying errdetails.RetryInfo retry delay
+	RetryDelayExtension = "retryDelay"
+	// ErrorInfoExtension extension member carrying errdetails.ErrorInfo
+	ErrorInfoExtension = "errorInfo"
+)
+
+// ErrorInfo reason of the error with its domain and metadata
+type ErrorInfo struct {
+	Reason   string            `json:"reason,omitempty"`
+	Domain   string            `json:"domain,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Unwrapper unwrap gRPC status error to status code, message and problem details error of FromStatus with its error details
+type Unwrapper struct{}
+
+var codeToStatus = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+}
+
+var statusToCode = map[int]codes.Code{
+	http.StatusOK:                           codes.OK,
+	http.StatusBadRequest:                   codes.InvalidArgument,
+	http.StatusUnauthorized:                 codes.Unauthenticated,
+	http.StatusForbidden:                    codes.PermissionDenied,
+	http.StatusNotFound:                     codes.NotFound,
+	http.StatusRequestTimeout:               codes.DeadlineExceeded,
+	http.StatusConflict:                     codes.AlreadyExists,
+	http.StatusPreconditionFailed:           codes.FailedPrecondition,
+	http.StatusRequestedRangeNotSatisfiable: codes.OutOfRange,
+	http.StatusUnprocessableEntity:          codes.InvalidArgument,
+	http.StatusTooManyRequests:              codes.ResourceExhausted,
+	499:                                     codes.Canceled,
+	http.StatusInternalServerError:          codes.Internal,
+	http.StatusNotImplemented:               codes.Unimplemented,
+	http.StatusServiceUnavailable:           codes.Unavailable,
+	http.StatusGatewayTimeout:               codes.DeadlineExceeded,
+}
+
+func init() {
+	problem.RegisterUnwrapper(Unwrapper{})
+}
+
+func (Unwrapper) Unwrap(_ http.ResponseWriter, err error) (int, string, error, bool) {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() == codes.OK {
+		return 0, "", err, false
+	}
+	return HTTPStatusFromCode(s.Code()), s.Message(), FromStatus(s).(error), true
+}
+
+// HTTPStatusFromCode map gRPC code to http status code
+func HTTPStatusFromCode(code codes.Code) int {
+	if statusCode, ok := codeToStatus[code]; ok {
+		return statusCode
+	}
+	return http.StatusInternalServerError
+}
+
+// CodeFromHTTPStatus map http status code to gRPC code
+func CodeFromHTTPStatus(statusCode int) codes.Code {
+	if code, ok := statusToCode[statusCode]; ok {
+		return code
+	}
+	switch {
+	case statusCode >= 200 && statusCode < 300:
+		return codes.OK
+	case statusCode >= 400 && statusCode < 500:
+		return codes.FailedPrecondition
+	case statusCode >= 500:
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// ToStatus convert problem details error to gRPC status, known extensions are carried as error details and
+// field errors of the problem as errdetails.BadRequest field violations
+func ToStatus(p problem.ProblemDetailErr) *status.Status {
+	message := p.GetDetails()
+	if message == "" {
+		message = p.GetTitle()
+	}
+	s := status.New(CodeFromHTTPStatus(p.GetStatus()), message)
+
+	var details []protoadapt.MessageV1
+	if fieldErrors, err := problem.GetFieldErrors(p); err == nil && len(fieldErrors) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, fieldErr := range fieldErrors {
+			field := fieldErr.Parameter
+			if fieldErr.Pointer != "" {
+				field = fieldErr.Pointer
+			}
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: field, Description: fieldErr.Reason})
+		}
+		details = append(details, badRequest)
+	}
+	var retryDelay string
+	if problem.DecodeExtension(p, RetryDelayExtension, &retryDelay) {
+		if delay, err := time.ParseDuration(retryDelay); err == nil {
+			details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+		}
+	}
+	var errorInfo ErrorInfo
+	if problem.DecodeExtension(p, ErrorInfoExtension, &errorInfo) {
+		details = append(details, &errdetails.ErrorInfo{Reason: errorInfo.Reason, Domain: errorInfo.Domain, Metadata: errorInfo.Metadata})
+	}
+
+	if withDetails, err := s.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return s
+}
+
+// FromStatus convert gRPC status to problem details error, known error details are carried as extensions and
+// errdetails.BadRequest field violations as field errors, the violations of JSON pointer fields as pointers
+func FromStatus(s *status.Status) problem.ProblemDetailErr {
+	statusCode := HTTPStatusFromCode(s.Code())
+	p := &problem.ProblemDetail{
+		Status: statusCode,
+		Title:  http.StatusText(statusCode),
+		Detail: s.Message(),
+	}
+
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, violation := range d.GetFieldViolations() {
+				fieldErr := problem.FieldError{Parameter: violation.GetField(), Reason: violation.GetDescription()}
+				if strings.HasPrefix(violation.GetField(), "/") {
+					fieldErr = problem.FieldError{Pointer: violation.GetField(), Location: problem.LocationBody, Reason: violation.GetDescription()}
+				}
+				problem.AttachFieldErrors(p, fieldErr)
+			}
+		case *errdetails.RetryInfo:
+			p.SetExtension(RetryDelayExtension, d.GetRetryDelay().AsDuration().String())
+		case *errdetails.ErrorInfo:
+			p.SetExtension(ErrorInfoExtension, ErrorInfo{Reason: d.GetReason(), Domain: d.GetDomain(), Metadata: d.GetMetadata()})
+		}
+	}
+	return p
+}
+
+// FromError convert error carrying gRPC status to problem details error, ok is false when the error has no gRPC status
+func FromError(err error) (problem.ProblemDetailErr, bool) {
+	s, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+	return FromStatus(s), true
+}
+
+// UnaryServerInterceptor convert problem details error returned from unary handler to gRPC status error, the problem
+// is resolved with the resolver first, so its mode, redaction and defaults apply, DefaultResolver is used when resolver is nil
+func UnaryServerInterceptor(resolver *problem.Resolver) grpc.UnaryServerInterceptor {
+	if resolver == nil {
+		resolver = problem.DefaultResolver
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, toStatusError(resolver, err)
+	}
+}
+
+// StreamServerInterceptor convert problem details error returned from stream handler to gRPC status error, the problem
+// is resolved with the resolver first, so its mode, redaction and defaults apply, DefaultResolver is used when resolver is nil
+func StreamServerInterceptor(resolver *problem.Resolver) grpc.StreamServerInterceptor {
+	if resolver == nil {
+		resolver = problem.DefaultResolver
+	}
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toStatusError(resolver, handler(srv, ss))
+	}
+}
+
+func toStatusError(resolver *problem.Resolver, err error) error {
+	var prob problem.ProblemDetailErr
+	if err == nil || !errors.As(err, &prob) {
+		return err
+	}
+	return ToStatus(resolver.ToProblemDetails(nil, err)).Err()
+}
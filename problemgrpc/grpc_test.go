@@ -0,0 +1,168 @@
+package problemgrpc
+
+import (
+	"context"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestToStatus_Details(t *testing.T) {
+
+	p := &problem.ProblemDetail{Status: http.StatusBadRequest, Title: "bad-request", Detail: "invalid order"}
+	problem.AttachFieldErrors(p, problem.FieldError{Parameter: "quantity", Reason: "must be positive"})
+	p.SetExtension(RetryDelayExtension, "1.5s")
+	p.SetExtension(ErrorInfoExtension, ErrorInfo{Reason: "INVALID_ORDER", Domain: "orders.example.com"})
+
+	s := ToStatus(p)
+
+	assert.Equal(t, codes.InvalidArgument, s.Code())
+	assert.Equal(t, "invalid order", s.Message())
+	assert.Len(t, s.Details(), 3)
+
+	badRequest := s.Details()[0].(*errdetails.BadRequest)
+	assert.Equal(t, "quantity", badRequest.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "must be positive", badRequest.GetFieldViolations()[0].GetDescription())
+	assert.Equal(t, 1500*time.Millisecond, s.Details()[1].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+	assert.Equal(t, "INVALID_ORDER", s.Details()[2].(*errdetails.ErrorInfo).GetReason())
+}
+
+func TestToStatus_Field_Errors(t *testing.T) {
+
+	p := problem.NewValidationProblem(problem.FieldError{Pointer: "/items/0/quantity", Location: problem.LocationBody, Reason: "is required", Code: "required"})
+
+	s := ToStatus(p)
+
+	assert.Equal(t, codes.InvalidArgument, s.Code())
+	badRequest := s.Details()[0].(*errdetails.BadRequest)
+	assert.Equal(t, "/items/0/quantity", badRequest.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "is required", badRequest.GetFieldViolations()[0].GetDescription())
+
+	fieldErrors, err := problem.GetFieldErrors(FromStatus(s))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []problem.FieldError{{Pointer: "/items/0/quantity", Location: problem.LocationBody, Reason: "is required"}}, fieldErrors)
+}
+
+func TestFromStatus_Details(t *testing.T) {
+
+	s, _ := status.New(codes.NotFound, "order 42 not found").WithDetails(
+		&errdetails.ErrorInfo{Reason: "ORDER_NOT_FOUND", Domain: "orders.example.com", Metadata: map[string]string{"id": "42"}})
+
+	p := FromStatus(s)
+
+	assert.Equal(t, http.StatusNotFound, p.GetStatus())
+	assert.Equal(t, "Not Found", p.GetTitle())
+	assert.Equal(t, "order 42 not found", p.GetDetails())
+	assert.Equal(t, ErrorInfo{Reason: "ORDER_NOT_FOUND", Domain: "orders.example.com", Metadata: map[string]string{"id": "42"}}, problem.Extensions(p)[ErrorInfoExtension])
+
+	assert.Equal(t, codes.NotFound, ToStatus(p).Code())
+}
+
+func TestResolve_Status_Error(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://grpc_endpoint1", nil)
+	rec := httptest.NewRecorder()
+
+	s, _ := status.New(codes.NotFound, "order 42 not found").WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "id", Description: "unknown order"}}},
+		&errdetails.ErrorInfo{Reason: "ORDER_NOT_FOUND", Domain: "orders.example.com"})
+
+	p, _ := problem.ResolveProblemDetails(rec, req, s.Err())
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "order 42 not found", p.GetDetails())
+	assert.Equal(t, http.StatusNotFound, p.GetStatus())
+	assert.Equal(t, []problem.FieldError{{Parameter: "id", Reason: "unknown order"}}, problem.Extensions(p)[problem.ErrorsExtension])
+	assert.Equal(t, ErrorInfo{Reason: "ORDER_NOT_FOUND", Domain: "orders.example.com"}, problem.Extensions(p)[ErrorInfoExtension])
+}
+
+func TestServerInterceptors(t *testing.T) {
+
+	client := newHealthClient(t, nil)
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "orders"})
+
+	s, _ := status.FromError(err)
+	assert.Equal(t, codes.NotFound, s.Code())
+	assert.Equal(t, "service orders is not registered", s.Message())
+
+	p, _ := FromError(err)
+	assert.Equal(t, http.StatusNotFound, p.GetStatus())
+	assert.Equal(t, []problem.FieldError{{Parameter: "service", Reason: "unknown service"}}, problem.Extensions(p)[problem.ErrorsExtension])
+
+	stream, _ := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "orders"})
+	_, err = stream.Recv()
+
+	s, _ = status.FromError(err)
+	assert.Equal(t, codes.Unavailable, s.Code())
+	assert.Equal(t, "watch is not available", s.Message())
+}
+
+func TestServerInterceptors_Resolver(t *testing.T) {
+
+	client := newHealthClient(t, problem.NewResolver(problem.WithMode(problem.ProductionMode)))
+
+	stream, _ := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "orders"})
+	_, err := stream.Recv()
+
+	s, _ := status.FromError(err)
+	assert.Equal(t, codes.Unavailable, s.Code())
+	assert.Equal(t, problem.GenericDetail, s.Message())
+
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "orders"})
+
+	s, _ = status.FromError(err)
+	assert.Equal(t, "service orders is not registered", s.Message())
+}
+
+func newHealthClient(t *testing.T, resolver *problem.Resolver) grpc_health_v1.HealthClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(resolver)),
+		grpc.StreamInterceptor(StreamServerInterceptor(resolver)))
+	grpc_health_v1.RegisterHealthServer(server, &healthServer{})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+}
+
+func (h *healthServer) Check(_ context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	p := &problem.ProblemDetail{Status: http.StatusNotFound, Detail: "service " + req.GetService() + " is not registered"}
+	problem.AttachFieldErrors(p, problem.FieldError{Parameter: "service", Reason: "unknown service"})
+	return nil, p
+}
+
+func (h *healthServer) Watch(_ *grpc_health_v1.HealthCheckRequest, _ grpc_health_v1.Health_WatchServer) error {
+	return &problem.ProblemDetail{Status: http.StatusServiceUnavailable, Detail: "watch is not available"}
+}
@@ -0,0 +1,69 @@
+// Package problemmsgpack encode and decode problem details error with MessagePack
+package problemmsgpack
+
+import (
+	"errors"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/tinylib/msgp/msgp"
+	"io"
+	"sort"
+)
+
+// ContentType media type of MessagePack problem details
+const ContentType = "application/problem+msgpack"
+
+type encoder struct{}
+
+// Encoder serialize problem details error with application/problem+msgpack media type for content negotiation of the resolver
+func Encoder() problem.Encoder {
+	return encoder{}
+}
+
+func (encoder) ContentType() string {
+	return ContentType
+}
+
+func (encoder) Encode(w io.Writer, p problem.ProblemDetailErr) error {
+	val, err := Marshal(p)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(val)
+	return err
+}
+
+// Marshal serialize problem details error including extension members to MessagePack map with sorted keys
+func Marshal(p problem.ProblemDetailErr) ([]byte, error) {
+	members, err := problem.Members(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for k := range members {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	val := msgp.AppendMapHeader(nil, uint32(len(names)))
+	for _, k := range names {
+		val = msgp.AppendString(val, k)
+		if val, err = msgp.AppendIntf(val, members[k]); err != nil {
+			return nil, err
+		}
+	}
+	return val, nil
+}
+
+// Decode deserialize MessagePack problem details, unknown members are collected as extensions
+func Decode(data []byte) (*problem.ProblemDetail, error) {
+	value, _, err := msgp.ReadIntfBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	members, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("problemmsgpack: problem details is not a map")
+	}
+	return problem.FromMembers(members)
+}
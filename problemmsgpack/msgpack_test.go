@@ -0,0 +1,58 @@
+package problemmsgpack
+
+import (
+	"github.com/meysamhadeli/problem-details"
+	"github.com/stretchr/testify/assert"
+	"github.com/tinylib/msgp/msgp"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMarshal_Decode(t *testing.T) {
+
+	p := &problem.ProblemDetail{Status: http.StatusForbidden, Title: "out of credit", Detail: "Your current balance is 30, but that costs 50."}
+	p.SetExtension("balance", 30)
+	p.SetExtension("limits", map[string]interface{}{"daily": 100})
+
+	val, err := Marshal(p)
+	assert.NoError(t, err)
+
+	raw, _, err := msgp.ReadIntfBytes(val)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(403), raw.(map[string]interface{})["status"])
+
+	decoded, err := Decode(val)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, decoded.GetStatus())
+	assert.Equal(t, "out of credit", decoded.GetTitle())
+	assert.Equal(t, float64(30), decoded.GetExtensions()["balance"])
+	assert.Equal(t, map[string]interface{}{"daily": float64(100)}, decoded.GetExtensions()["limits"])
+}
+
+func TestDecode_Not_Map(t *testing.T) {
+
+	_, err := Decode(msgp.AppendString(nil, "problem"))
+
+	assert.Error(t, err)
+}
+
+func TestEncoder_Negotiation(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://msgpack_endpoint1", nil)
+	req.Header.Set("Accept", ContentType)
+	rec := httptest.NewRecorder()
+
+	resolver := problem.NewResolver(problem.WithEncoders(problem.JSONEncoder(), Encoder()))
+
+	_, _ = resolver.ResolveProblemDetails(rec, req, &problem.ProblemDetail{Status: http.StatusNotFound, Detail: "order 42 not found"})
+
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+
+	decoded, err := Decode(rec.Body.Bytes())
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, decoded.GetStatus())
+	assert.Equal(t, "order 42 not found", decoded.GetDetails())
+}
@@ -0,0 +1,105 @@
+// Package problemtwirp convert problem details error to twirp error and back, and register twirp error unwrapper for resolve problem details error
+package problemtwirp
+
+import (
+	"errors"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/twitchtv/twirp"
+	"net/http"
+)
+
+// MetaExtension extension member carrying twirp error meta
+const MetaExtension = "meta"
+
+// Unwrapper unwrap twirp error to status code, message and problem details error of FromError with its meta
+type Unwrapper struct{}
+
+var statusToCode = map[int]twirp.ErrorCode{
+	http.StatusBadRequest:                   twirp.InvalidArgument,
+	http.StatusUnauthorized:                 twirp.Unauthenticated,
+	http.StatusForbidden:                    twirp.PermissionDenied,
+	http.StatusNotFound:                     twirp.NotFound,
+	http.StatusRequestTimeout:               twirp.DeadlineExceeded,
+	http.StatusConflict:                     twirp.AlreadyExists,
+	http.StatusPreconditionFailed:           twirp.FailedPrecondition,
+	http.StatusRequestedRangeNotSatisfiable: twirp.OutOfRange,
+	http.StatusUnprocessableEntity:          twirp.InvalidArgument,
+	http.StatusTooManyRequests:              twirp.ResourceExhausted,
+	499:                                     twirp.Canceled,
+	http.StatusInternalServerError:          twirp.Internal,
+	http.StatusNotImplemented:               twirp.Unimplemented,
+	http.StatusServiceUnavailable:           twirp.Unavailable,
+	http.StatusGatewayTimeout:               twirp.DeadlineExceeded,
+}
+
+func init() {
+	problem.RegisterUnwrapper(Unwrapper{})
+}
+
+func (Unwrapper) Unwrap(_ http.ResponseWriter, err error) (int, string, error, bool) {
+	var twirpError twirp.Error
+	if !errors.As(err, &twirpError) {
+		return 0, "", err, false
+	}
+	p, _ := FromError(twirpError)
+	return HTTPStatusFromCode(twirpError.Code()), twirpError.Msg(), p.(error), true
+}
+
+// HTTPStatusFromCode map twirp error code to http status code
+func HTTPStatusFromCode(code twirp.ErrorCode) int {
+	if statusCode := twirp.ServerHTTPStatusFromErrorCode(code); statusCode != 0 {
+		return statusCode
+	}
+	return http.StatusInternalServerError
+}
+
+// CodeFromHTTPStatus map http status code to twirp error code
+func CodeFromHTTPStatus(statusCode int) twirp.ErrorCode {
+	if code, ok := statusToCode[statusCode]; ok {
+		return code
+	}
+	if statusCode >= 400 && statusCode < 500 {
+		return twirp.FailedPrecondition
+	}
+	if statusCode >= 500 {
+		return twirp.Internal
+	}
+	return twirp.Unknown
+}
+
+// ToError convert problem details error to twirp error, meta extension is carried as error meta
+func ToError(p problem.ProblemDetailErr) twirp.Error {
+	message := p.GetDetails()
+	if message == "" {
+		message = p.GetTitle()
+	}
+	twirpError := twirp.NewError(CodeFromHTTPStatus(p.GetStatus()), message)
+
+	var meta map[string]string
+	if problem.DecodeExtension(p, MetaExtension, &meta) {
+		for k, v := range meta {
+			twirpError = twirpError.WithMeta(k, v)
+		}
+	}
+	return twirpError
+}
+
+// FromError convert twirp error to problem details error, error meta is carried as meta extension,
+// ok is false when the error is not a twirp error
+func FromError(err error) (problem.ProblemDetailErr, bool) {
+	var twirpError twirp.Error
+	if !errors.As(err, &twirpError) {
+		return nil, false
+	}
+
+	statusCode := HTTPStatusFromCode(twirpError.Code())
+	p := &problem.ProblemDetail{
+		Status: statusCode,
+		Title:  http.StatusText(statusCode),
+		Detail: twirpError.Msg(),
+	}
+	if meta := twirpError.MetaMap(); len(meta) > 0 {
+		p.SetExtension(MetaExtension, meta)
+	}
+	return p, true
+}
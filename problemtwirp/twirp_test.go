@@ -0,0 +1,51 @@
+package problemtwirp
+
+import (
+	"github.com/meysamhadeli/problem-details"
+	"github.com/stretchr/testify/assert"
+	"github.com/twitchtv/twirp"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromError_Meta(t *testing.T) {
+
+	twirpError := twirp.InvalidArgumentError("quantity", "must be positive")
+
+	p, ok := FromError(twirpError)
+
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, p.GetStatus())
+	assert.Equal(t, "Bad Request", p.GetTitle())
+	assert.Equal(t, "quantity must be positive", p.GetDetails())
+	assert.Equal(t, map[string]string{"argument": "quantity"}, problem.Extensions(p)[MetaExtension])
+}
+
+func TestToError_Meta(t *testing.T) {
+
+	p := &problem.ProblemDetail{Status: http.StatusNotFound, Title: "not-found", Detail: "order 42 not found"}
+	p.SetExtension(MetaExtension, map[string]interface{}{"id": "42"})
+
+	twirpError := ToError(p)
+
+	assert.Equal(t, twirp.NotFound, twirpError.Code())
+	assert.Equal(t, "order 42 not found", twirpError.Msg())
+	assert.Equal(t, "42", twirpError.Meta("id"))
+}
+
+func TestResolve_Twirp_Error(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://twirp_endpoint1", nil)
+	rec := httptest.NewRecorder()
+
+	err := twirp.NewError(twirp.Unavailable, "payment service is not available").WithMeta("retry_after", "30")
+
+	p, _ := problem.ResolveProblemDetails(rec, req, err)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "payment service is not available", p.GetDetails())
+	assert.Equal(t, http.StatusServiceUnavailable, p.GetStatus())
+	assert.Equal(t, map[string]string{"retry_after": "30"}, problem.Extensions(p)[MetaExtension])
+	assert.Contains(t, rec.Body.String(), `"meta":{"retry_after":"30"}`)
+}
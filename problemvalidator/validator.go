@@ -0,0 +1,145 @@
+// Package problemvalidator register go-playground/validator error unwrapper for resolve validation errors to 422
+// problem details error with errors extension member
+package problemvalidator
+
+import (
+	"errors"
+	"fmt"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/meysamhadeli/problem-details"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Unwrapper unwrap validator.ValidationErrors to 422 problem details error, the unwrapper registered on import uses the
+// zero Options, install an unwrapper created with New on a resolver with problem.WithUnwrappers for other options
+type Unwrapper struct {
+	opts Options
+}
+
+// Options options of resolving validation errors
+type Options struct {
+	// Translator translate the messages with the translations registered on the validator for the translator
+	Translator ut.Translator
+	// Location location of the invalid fields, problem.LocationBody when empty
+	Location problem.Location
+}
+
+// optionsError validation error carrying the options of resolving it
+type optionsError struct {
+	err  error
+	opts Options
+}
+
+var indexPattern = regexp.MustCompile(`\[([^\]]*)\]`)
+
+func init() {
+	problem.RegisterUnwrapper(Unwrapper{})
+}
+
+// New create unwrapper resolving validation errors with the options
+func New(opts Options) Unwrapper {
+	return Unwrapper{opts: opts}
+}
+
+// WithOptions attach options to the validation error, e.g. translator of the locale of the request, set options win
+// over the options of the unwrapper
+func WithOptions(err error, opts Options) error {
+	if err == nil {
+		return nil
+	}
+	return &optionsError{err: err, opts: opts}
+}
+
+func (e *optionsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *optionsError) Unwrap() error {
+	return e.err
+}
+
+func (u Unwrapper) Unwrap(_ http.ResponseWriter, err error) (int, string, error, bool) {
+	opts := u.opts
+	var optsErr *optionsError
+	if errors.As(err, &optsErr) {
+		if optsErr.opts.Translator != nil {
+			opts.Translator = optsErr.opts.Translator
+		}
+		if optsErr.opts.Location != "" {
+			opts.Location = optsErr.opts.Location
+		}
+		err = optsErr.err
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return 0, "", err, false
+	}
+	return http.StatusUnprocessableEntity, "", FromValidationErrors(validationErrors, opts), true
+}
+
+// FromValidationErrors convert validation errors to 422 problem details error with field errors in errors extension
+// member, the failed tag is the code of the field error and the parameter of the tag is its param, messages are
+// translated when the translator of the options isn't nil
+func FromValidationErrors(validationErrors validator.ValidationErrors, opts Options) *problem.ProblemDetail {
+	location := opts.Location
+	if location == "" {
+		location = problem.LocationBody
+	}
+
+	errs := make([]problem.FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		errs = append(errs, problem.FieldError{
+			Pointer:  Pointer(fieldErr),
+			Location: location,
+			Reason:   message(fieldErr, opts.Translator),
+			Code:     fieldErr.Tag(),
+			Param:    fieldErr.Param(),
+		})
+	}
+
+	p := &problem.ProblemDetail{
+		Status: http.StatusUnprocessableEntity,
+		Title:  http.StatusText(http.StatusUnprocessableEntity),
+		Detail: fmt.Sprintf("request has %d invalid fields", len(errs)),
+	}
+	problem.AttachFieldErrors(p, errs...)
+	return p
+}
+
+// Pointer JSON pointer of the field of validation error, register JSONTagName on the validator to use json names of the fields
+func Pointer(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		namespace = path
+	}
+	return problem.JSONPointer(indexPattern.ReplaceAllString(namespace, ".$1"))
+}
+
+// JSONTagName name of the field in json tag, register it on the validator with RegisterTagNameFunc
+func JSONTagName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+func message(fieldErr validator.FieldError, trans ut.Translator) string {
+	if trans != nil {
+		if msg := fieldErr.Translate(trans); msg != fieldErr.Error() {
+			return msg
+		}
+	}
+	if fieldErr.Param() != "" {
+		return fmt.Sprintf("failed on the '%s=%s' validation", fieldErr.Tag(), fieldErr.Param())
+	}
+	return fmt.Sprintf("failed on the '%s' validation", fieldErr.Tag())
+}
@@ -0,0 +1,92 @@
+package problemvalidator
+
+import (
+	"fmt"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	"github.com/meysamhadeli/problem-details"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type order struct {
+	Customer string `json:"customer" validate:"required"`
+	Items    []item `json:"items" validate:"required,dive"`
+}
+
+type item struct {
+	Quantity int `json:"quantity" validate:"gte=1"`
+}
+
+func TestResolve_Validation_Errors(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodPost, "http://validator_endpoint1", nil)
+	rec := httptest.NewRecorder()
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(JSONTagName)
+
+	err := validate.Struct(order{Items: []item{{Quantity: 2}, {Quantity: 0}}})
+
+	p, _ := problem.ResolveProblemDetails(rec, req, err)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "request has 2 invalid fields", p.GetDetails())
+	assert.Equal(t, []problem.FieldError{
+		{Pointer: "/customer", Location: problem.LocationBody, Reason: "failed on the 'required' validation", Code: "required"},
+		{Pointer: "/items/1/quantity", Location: problem.LocationBody, Reason: "failed on the 'gte=1' validation", Code: "gte", Param: "1"},
+	}, problem.Extensions(p)[problem.ErrorsExtension])
+	assert.Contains(t, rec.Body.String(), `"errors":[{"pointer":"/customer","location":"body","detail":"failed on the 'required' validation","code":"required"}`)
+}
+
+func TestResolve_Validation_Errors_Translated(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodPost, "http://validator_endpoint2", nil)
+	rec := httptest.NewRecorder()
+
+	english := en.New()
+	trans, _ := ut.New(english, english).GetTranslator("en")
+	validate := validator.New()
+	validate.RegisterTagNameFunc(JSONTagName)
+	_ = entranslations.RegisterDefaultTranslations(validate, trans)
+
+	resolver := problem.NewResolver(problem.WithUnwrappers(New(Options{Translator: trans})))
+
+	err := validate.Struct(order{Customer: "john", Items: []item{{Quantity: 0}}})
+
+	p, _ := resolver.ResolveProblemDetails(rec, req, err)
+
+	assert.Equal(t, []problem.FieldError{
+		{Pointer: "/items/0/quantity", Location: problem.LocationBody, Reason: "quantity must be 1 or greater", Code: "gte", Param: "1"},
+	}, problem.Extensions(p)[problem.ErrorsExtension])
+
+	p = problem.ToProblemDetails(req, err)
+
+	assert.Equal(t, "failed on the 'gte=1' validation", problem.Extensions(p)[problem.ErrorsExtension].([]problem.FieldError)[0].Reason)
+}
+
+func TestResolve_Validation_Errors_With_Options(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://validator_endpoint3/orders?page=0", nil)
+
+	english := en.New()
+	trans, _ := ut.New(english, english).GetTranslator("en")
+	validate := validator.New()
+	validate.RegisterTagNameFunc(JSONTagName)
+	_ = entranslations.RegisterDefaultTranslations(validate, trans)
+
+	err := validate.Struct(struct {
+		Page int `json:"page" validate:"gte=1"`
+	}{})
+
+	p := problem.ToProblemDetails(req, fmt.Errorf("list orders: %w", WithOptions(err, Options{Translator: trans, Location: problem.LocationQuery})))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, p.GetStatus())
+	assert.Equal(t, []problem.FieldError{
+		{Pointer: "/page", Location: problem.LocationQuery, Reason: "page must be 1 or greater", Code: "gte", Param: "1"},
+	}, problem.Extensions(p)[problem.ErrorsExtension])
+}
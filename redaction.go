@@ -0,0 +1,204 @@
+package problem
+
+import (
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// DefaultReplacement replacement of the redacted values when RedactionOptions has no replacement
+const DefaultReplacement = "[REDACTED]"
+
+// RedactionPolicy policy of redacting a member of the problem details
+type RedactionPolicy int
+
+const (
+	// RedactDetected replace values found by the detectors, the default policy of the members
+	RedactDetected RedactionPolicy = iota
+	// RedactAll replace the whole member
+	RedactAll
+	// RedactNone keep the member as it is
+	RedactNone
+)
+
+// Detector detect sensitive values in string members of the problem details
+type Detector struct {
+	// Name name of the detected values, e.g. email
+	Name string
+	// Pattern match candidates of the sensitive values
+	Pattern *regexp.Regexp
+	// Valid report whether the candidate is a sensitive value, all candidates are redacted when it is nil
+	Valid func(candidate string) bool
+}
+
+// RedactionOptions options of redacting sensitive values of the problem details before they are written
+type RedactionOptions struct {
+	// Detectors detectors of the sensitive values, DefaultDetectors are used when it is nil
+	Detectors []Detector
+	// Replacement replacement of the redacted values, DefaultReplacement is used when it is empty
+	Replacement string
+	// Fields policies of the members by their name, e.g. detail or an extension member, members without policy use RedactDetected
+	Fields map[string]RedactionPolicy
+}
+
+// EmailDetector detect email addresses
+func EmailDetector() Detector {
+	return Detector{Name: "email", Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)}
+}
+
+// JWTDetector detect json web tokens
+func JWTDetector() Detector {
+	return Detector{Name: "jwt", Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)}
+}
+
+// CreditCardDetector detect credit card numbers which pass Luhn checksum, digits can be separated with spaces or dashes
+func CreditCardDetector() Detector {
+	return Detector{Name: "credit-card", Pattern: regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`), Valid: luhn}
+}
+
+// IPDetector detect IPv4 and IPv6 addresses
+func IPDetector() Detector {
+	return Detector{
+		Name:    "ip",
+		Pattern: regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b|(?:[0-9A-Fa-f]{0,4}:){2,7}[0-9A-Fa-f]{0,4}`),
+		Valid: func(candidate string) bool {
+			return net.ParseIP(candidate) != nil
+		},
+	}
+}
+
+// RegexpDetector detect values matching the regular expression
+func RegexpDetector(name string, pattern string) Detector {
+	return Detector{Name: name, Pattern: regexp.MustCompile(pattern)}
+}
+
+// DefaultDetectors detectors of email addresses, json web tokens, credit card numbers and IP addresses
+func DefaultDetectors() []Detector {
+	return []Detector{JWTDetector(), EmailDetector(), CreditCardDetector(), IPDetector()}
+}
+
+var defaultDetectors = DefaultDetectors()
+
+// redact redact string members and extensions of the problem with their policies
+func (o RedactionOptions) redact(p ProblemDetailErr) {
+	p.SetTitle(o.redactMember("title", p.GetTitle()))
+	p.SetDetail(o.redactMember("detail", p.GetDetails()))
+	p.SetType(o.redactMember("type", p.GetType()))
+	p.SetInstance(o.redactMember("instance", p.GetInstance()))
+	p.SetStackTrace(o.redactMember("stackTrace", p.GetStackTrace()))
+
+	customFields(p, func(name string, field reflect.Value, _ bool) {
+		if field.Kind() == reflect.String && field.CanSet() {
+			field.SetString(o.redactMember(name, field.String()))
+		}
+	})
+
+	for name, value := range Extensions(p) {
+		policy := o.Fields[name]
+		if policy == RedactNone {
+			continue
+		}
+		if policy == RedactAll {
+			Extensions(p)[name] = o.replacement()
+			continue
+		}
+		if redacted, ok := o.redactValue(value); ok {
+			Extensions(p)[name] = redacted
+		}
+	}
+}
+
+func (o RedactionOptions) redactMember(name string, value string) string {
+	if value == "" {
+		return value
+	}
+	switch o.Fields[name] {
+	case RedactNone:
+		return value
+	case RedactAll:
+		return o.replacement()
+	}
+	return o.redactString(value)
+}
+
+// redactValue redact strings of the value recursively, ok is false when the value has nothing to redact
+func (o RedactionOptions) redactValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case nil, bool, int, int64, float64:
+		return value, false
+	case string:
+		redacted := o.redactString(v)
+		return redacted, redacted != v
+	case []interface{}:
+		var changed bool
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			var ok bool
+			items[i], ok = o.redactValue(item)
+			changed = changed || ok
+		}
+		return items, changed
+	case map[string]interface{}:
+		var changed bool
+		members := make(map[string]interface{}, len(v))
+		for k, member := range v {
+			var ok bool
+			members[k], ok = o.redactValue(member)
+			changed = changed || ok
+		}
+		return members, changed
+	}
+
+	normalized, err := normalizeValue(value)
+	if err != nil {
+		return value, false
+	}
+	if redacted, ok := o.redactValue(convertNumbers(normalized)); ok {
+		return redacted, true
+	}
+	return value, false
+}
+
+func (o RedactionOptions) redactString(s string) string {
+	detectors := o.Detectors
+	if detectors == nil {
+		detectors = defaultDetectors
+	}
+	for _, detector := range detectors {
+		s = detector.Pattern.ReplaceAllStringFunc(s, func(candidate string) string {
+			if detector.Valid != nil && !detector.Valid(candidate) {
+				return candidate
+			}
+			return o.replacement()
+		})
+	}
+	return s
+}
+
+func (o RedactionOptions) replacement() string {
+	if o.Replacement == "" {
+		return DefaultReplacement
+	}
+	return o.Replacement
+}
+
+// luhn report whether the digits of the number pass Luhn checksum
+func luhn(number string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+
+	var sum int
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
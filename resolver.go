@@ -0,0 +1,242 @@
+package problem
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// Resolver resolve error with format problem details error and write it to response base on its options
+type Resolver struct {
+	encoder          Encoder
+	encoders         []Encoder
+	fallback         Encoder
+	jsonOptions      JSONOptions
+	typeResolver     TypeResolver
+	instanceResolver InstanceResolver
+	mode             Mode
+	logHook          LogHook
+	debugVerifier    DebugVerifier
+	stackOptions     *StackOptions
+	redaction        *RedactionOptions
+	limits           SizeLimits
+	mappings         []Mapping
+	unwrappers       []Unwrapper
+}
+
+// maxPooledBufferSize bound of buffers returned to the pool, to not keep the memory of oversized responses
+const maxPooledBufferSize = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// Option configure Resolver
+type Option func(*Resolver)
+
+// DefaultResolver resolver used by ResolveProblemDetails and ToProblemDetails
+var DefaultResolver = NewResolver()
+
+// NewResolver create problem details resolver, without options it negotiates between application/problem+json,
+// application/problem+xml, application/json, text/html and text/plain on Accept header of the request and writes application/problem+json by default
+func NewResolver(opts ...Option) *Resolver {
+	rs := &Resolver{}
+	for _, opt := range opts {
+		opt(rs)
+	}
+
+	jsonEncoder := NewJSONEncoder(rs.jsonOptions)
+	if rs.encoder == nil {
+		rs.encoder = jsonEncoder
+	}
+	if rs.encoders == nil {
+		rs.encoders = []Encoder{jsonEncoder, XMLEncoder(), ContentTypeEncoder("application/json", jsonEncoder), HTMLEncoder(), TextEncoder()}
+	}
+	if rs.fallback == nil {
+		rs.fallback = rs.encoder
+	}
+	if rs.typeResolver == nil {
+		rs.typeResolver = AboutBlankType()
+	}
+	if rs.instanceResolver == nil {
+		rs.instanceResolver = RequestURIInstance()
+	}
+	return rs
+}
+
+// WithEncoder select default encoder for serialize problem details response when Accept header is missing or
+// the default encoder is one of the most acceptable ones
+func WithEncoder(encoder Encoder) Option {
+	return func(rs *Resolver) {
+		rs.encoder = encoder
+	}
+}
+
+// WithEncoders set encoders to negotiate on Accept header of the request, without encoders the default encoder is always used
+func WithEncoders(encoders ...Encoder) Option {
+	return func(rs *Resolver) {
+		rs.encoders = append([]Encoder{}, encoders...)
+	}
+}
+
+// WithJSONOptions configure codec and options of the built-in json encoders of the resolver
+func WithJSONOptions(opts JSONOptions) Option {
+	return func(rs *Resolver) {
+		rs.jsonOptions = opts
+	}
+}
+
+// WithFallbackEncoder select encoder for clients which accept none of the encoders, the default encoder is used when it isn't set
+func WithFallbackEncoder(encoder Encoder) Option {
+	return func(rs *Resolver) {
+		rs.fallback = encoder
+	}
+}
+
+// WithTypeResolver set resolver of type for problems without type, about:blank is used when it isn't set
+func WithTypeResolver(typeResolver TypeResolver) Option {
+	return func(rs *Resolver) {
+		rs.typeResolver = typeResolver
+	}
+}
+
+// WithInstanceResolver set resolver of instance for problems without instance, request URI is used when it isn't set
+func WithInstanceResolver(instanceResolver InstanceResolver) Option {
+	return func(rs *Resolver) {
+		rs.instanceResolver = instanceResolver
+	}
+}
+
+// WithMode set mode of the resolver, in ProductionMode stack traces are omitted and detail of server errors is replaced
+// with GenericDetail
+func WithMode(mode Mode) Option {
+	return func(rs *Resolver) {
+		rs.mode = mode
+	}
+}
+
+// WithLogHook set hook which receives every resolved error with the full information of its problem details
+func WithLogHook(hook LogHook) Option {
+	return func(rs *Resolver) {
+		rs.logHook = hook
+	}
+}
+
+// WithDebugVerifier set verifier of debug credential of the request, stack trace and cause chain of the error are
+// included only in responses of verified requests, also in production mode
+func WithDebugVerifier(verifier DebugVerifier) Option {
+	return func(rs *Resolver) {
+		rs.debugVerifier = verifier
+	}
+}
+
+// WithStackFrames capture structured stack frames of the errors into stack extension member instead of stackTrace
+func WithStackFrames(opts StackOptions) Option {
+	return func(rs *Resolver) {
+		rs.stackOptions = &opts
+	}
+}
+
+// WithRedaction redact sensitive values of string members and extensions of the problems before they are written
+func WithRedaction(opts RedactionOptions) Option {
+	return func(rs *Resolver) {
+		rs.redaction = &opts
+	}
+}
+
+// WithSizeLimits limit sizes of detail, stack trace, string extensions and body of the problems when they are written
+func WithSizeLimits(limits SizeLimits) Option {
+	return func(rs *Resolver) {
+		rs.limits = limits
+	}
+}
+
+// WithMappings install mappings of errors on the resolver, e.g. StandardMappings(), a mapping replaces the installed
+// mapping with the same name, NewResolver panics when a mapping has no Match or Problem
+func WithMappings(mappings ...Mapping) Option {
+	return func(rs *Resolver) {
+		rs.installMappings(mappings)
+	}
+}
+
+// WithUnwrappers install error envelope unwrappers on the resolver, they are tried before the registered unwrappers at
+// each error of the chain, e.g. to resolve validation errors with the options of the resolver
+func WithUnwrappers(unwrappers ...Unwrapper) Option {
+	return func(rs *Resolver) {
+		rs.unwrappers = append(rs.unwrappers, unwrappers...)
+	}
+}
+
+// ResolveProblemDetails retrieve and resolve error with format problem details error and write it to response
+func (rs *Resolver) ResolveProblemDetails(w http.ResponseWriter, r *http.Request, err error) (ProblemDetailErr, error) {
+	var p, unhandledErr = rs.resolve(w, r, err)
+
+	_, err = rs.writeTo(w, r, p)
+	if err != nil {
+		return nil, err
+	}
+	return p, unhandledErr
+}
+
+// ToProblemDetails resolve error with format problem details error without writing it to response,
+// r can be nil when the error doesn't belong to a http request
+func (rs *Resolver) ToProblemDetails(r *http.Request, err error) ProblemDetailErr {
+	var p, _ = rs.resolve(nil, r, err)
+	return p
+}
+
+func (rs *Resolver) resolve(w http.ResponseWriter, r *http.Request, err error) (ProblemDetailErr, error) {
+	var p, unhandledErr = rs.resolveProblem(w, r, err)
+
+	if rs.logHook != nil {
+		rs.logHook(r, err, p)
+	}
+
+	var debug = rs.debugVerifier != nil && r != nil && rs.debugVerifier.Verify(r)
+	if debug {
+		SetExtension(p, CausesExtension, causeChain(err))
+	} else {
+		if rs.debugVerifier != nil {
+			p.SetStackTrace("")
+			delete(Extensions(p), StackExtension)
+		}
+		if rs.mode == ProductionMode {
+			hideInternals(p)
+		}
+	}
+
+	if rs.redaction != nil {
+		rs.redaction.redact(p)
+	}
+	return p, unhandledErr
+}
+
+func (rs *Resolver) writeTo(w http.ResponseWriter, r *http.Request, p ProblemDetailErr) (int, error) {
+	encoder := rs.negotiate(r)
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer putBuffer(buf)
+
+	rs.limits.truncate(p)
+	if err := rs.limits.encode(buf, encoder, p); err != nil {
+		return 0, err
+	}
+
+	if len(rs.encoders) > 0 && !slices.Contains(w.Header().Values("Vary"), "Accept") {
+		w.Header().Add("Vary", "Accept")
+	}
+	w.Header().Set("Content-Type", encoder.ContentType())
+	w.WriteHeader(p.GetStatus())
+	return w.Write(buf.Bytes())
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
@@ -0,0 +1,262 @@
+package problem
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolver_Type_URI(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+	rec := httptest.NewRecorder()
+
+	resolver := NewResolver(WithTypeResolver(TypeURI("https://errors.example.com/{status}")))
+
+	p, _ := resolver.ResolveProblemDetails(rec, req, endpoint3())
+
+	assert.Equal(t, "https://errors.example.com/500", p.GetType())
+	assert.Equal(t, "https://errors.example.com/499", TypeURI("https://errors.example.com/{status}").ResolveType(499))
+	assert.Equal(t, AboutBlank, ToProblemDetails(req, endpoint3()).GetType())
+}
+
+func TestResolver_Instance_Resolvers(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "https://api.example.com/orders/42?token=secret", nil)
+
+	assert.Equal(t, "/orders/42?token=secret", ToProblemDetails(req, endpoint3()).GetInstance())
+	assert.Equal(t, "/orders/42", NewResolver(WithInstanceResolver(PathInstance())).ToProblemDetails(req, endpoint3()).GetInstance())
+	assert.Equal(t, "https://api.example.com/orders/42", NewResolver(WithInstanceResolver(AbsoluteURLInstance(""))).ToProblemDetails(req, endpoint3()).GetInstance())
+
+	req.Host = "attacker.example.net"
+	assert.Equal(t, "https://public.example.com/v1/orders/42", NewResolver(WithInstanceResolver(AbsoluteURLInstance("https://public.example.com/v1/"))).ToProblemDetails(req, endpoint3()).GetInstance())
+
+	resolver := NewResolver(WithInstanceResolver(RequestIDInstance("")))
+	first := resolver.ToProblemDetails(req, endpoint3()).GetInstance()
+	second := resolver.ToProblemDetails(nil, endpoint3()).GetInstance()
+
+	assert.Regexp(t, `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, first)
+	assert.NotEqual(t, first, second)
+
+	req.Header.Set(RequestIDHeader, "01HV6BGKQ7Z3X9R2M4N8T5W0YC")
+	first = resolver.ToProblemDetails(req, endpoint3()).GetInstance()
+	p := resolver.ToProblemDetails(req, endpoint3())
+
+	assert.Regexp(t, `^urn:uuid:[0-9a-f-]{36}$`, p.GetInstance())
+	assert.NotEqual(t, first, p.GetInstance())
+	assert.Equal(t, "01HV6BGKQ7Z3X9R2M4N8T5W0YC", Extensions(p)[RequestIDExtension])
+
+	req.Header.Set(RequestIDHeader, "<script>alert(1)</script>")
+	assert.NotContains(t, Extensions(resolver.ToProblemDetails(req, endpoint3())), RequestIDExtension)
+
+	req.Header.Set(RequestIDHeader, strings.Repeat("a", 129))
+	assert.NotContains(t, Extensions(resolver.ToProblemDetails(req, endpoint3())), RequestIDExtension)
+}
+
+func TestResolver_Production_Mode(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+	rec := httptest.NewRecorder()
+
+	var logged ProblemDetailErr
+	var loggedDetail, loggedStackTrace string
+	resolver := NewResolver(WithMode(ProductionMode), WithLogHook(func(r *http.Request, err error, p ProblemDetailErr) {
+		logged, loggedDetail, loggedStackTrace = p, p.GetDetails(), p.GetStackTrace()
+	}))
+
+	err := endpoint3()
+	p, _ := resolver.ResolveProblemDetails(rec, req, err)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, GenericDetail, p.GetDetails())
+	assert.Equal(t, "", p.GetStackTrace())
+	assert.NotContains(t, rec.Body.String(), err.Error())
+
+	assert.Same(t, p, logged)
+	assert.Equal(t, err.Error(), loggedDetail)
+	assert.NotEmpty(t, loggedStackTrace)
+
+	p = resolver.ToProblemDetails(req, &ProblemDetail{Status: http.StatusNotFound, Detail: "order 42 not found"})
+
+	assert.Equal(t, "order 42 not found", p.GetDetails())
+	assert.Equal(t, "", p.GetStackTrace())
+}
+
+func TestResolver_Debug_Verifier(t *testing.T) {
+
+	secret := []byte("debug-secret")
+	resolver := NewResolver(WithMode(ProductionMode), WithDebugVerifier(HMACVerifier("", secret, time.Minute)))
+
+	err := fmt.Errorf("load order: %w", endpoint3())
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+	p := resolver.ToProblemDetails(req, err)
+
+	assert.Equal(t, "", p.GetStackTrace())
+	assert.Equal(t, GenericDetail, p.GetDetails())
+	assert.Nil(t, Extensions(p)[CausesExtension])
+
+	req.Header.Set(DebugHeader, SignDebugToken([]byte("wrong-secret"), time.Now()))
+	assert.Equal(t, "", resolver.ToProblemDetails(req, err).GetStackTrace())
+
+	req.Header.Set(DebugHeader, SignDebugToken(secret, time.Now().Add(-time.Hour)))
+	assert.Equal(t, "", resolver.ToProblemDetails(req, err).GetStackTrace())
+
+	req.Header.Set(DebugHeader, SignDebugToken(secret, time.Now()))
+	p = resolver.ToProblemDetails(req, err)
+
+	assert.NotEmpty(t, p.GetStackTrace())
+	assert.Equal(t, err.Error(), p.GetDetails())
+	assert.Equal(t, []string{err.Error(), endpoint3().Error()}, Extensions(p)[CausesExtension])
+}
+
+func TestResolver_Stack_Frames(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+
+	keepTests := func(frame StackFrame) bool {
+		return strings.Contains(frame.Function, ".Test")
+	}
+	resolver := NewResolver(WithStackFrames(StackOptions{
+		Depth:        1,
+		TrimPrefixes: []string{"github.com/meysamhadeli/problem-details"},
+		Filter:       keepTests,
+	}))
+
+	err := fmt.Errorf("load order: %w", newTracedError("connection refused"))
+	p := resolver.ToProblemDetails(req, err)
+
+	assert.Equal(t, "", p.GetStackTrace())
+	frames := Extensions(p)[StackExtension].([]StackFrame)
+	assert.Len(t, frames, 1)
+	assert.Equal(t, "TestResolver_Stack_Frames", frames[0].Function)
+	assert.True(t, strings.HasSuffix(frames[0].File, "resolver_test.go"))
+	assert.NotZero(t, frames[0].Line)
+
+	frames = StackFrames(endpoint3(), StackOptions{Filter: keepTests})
+	assert.Equal(t, "github.com/meysamhadeli/problem-details.TestResolver_Stack_Frames", frames[0].Function)
+
+	frames = StackFrames(endpoint3(), StackOptions{})
+	assert.Empty(t, frames)
+}
+
+func BenchmarkResolveProblemDetails_Default(b *testing.B) {
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3/orders/42", nil)
+	err := errors.New("dial tcp 10.0.0.1:5432: connection refused")
+	w := &discardResponseWriter{header: http.Header{}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ResolveProblemDetails(w, req, err)
+	}
+}
+
+func BenchmarkResolveProblemDetails_MapStatus(b *testing.B) {
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint2/orders/42", nil)
+	err := statusError{Code: http.StatusServiceUnavailable, InternalError: errors.New("payment service is not available")}
+	w := &discardResponseWriter{header: http.Header{}}
+
+	resolver := NewResolver(WithUnwrappers(UnwrapAs(func(err statusError) (int, string, error) {
+		return err.Code, "", err.InternalError
+	})))
+	MapStatus(http.StatusServiceUnavailable, func() ProblemDetailErr {
+		return &ProblemDetail{
+			Status: http.StatusServiceUnavailable,
+			Title:  "service-unavailable",
+			Type:   "https://errors.example.com/service-unavailable",
+		}
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = resolver.ResolveProblemDetails(w, req, err)
+	}
+}
+
+func BenchmarkJSONEncoder_ProblemDetail(b *testing.B) {
+	p := benchmarkProblem()
+	encoder := JSONEncoder()
+	var buf bytes.Buffer
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		_ = encoder.Encode(&buf, p)
+	}
+}
+
+func BenchmarkJSONEncoder_ProblemDetail_Reflection(b *testing.B) {
+	p := benchmarkProblem()
+	encoder := NewJSONEncoder(JSONOptions{Marshal: json.Marshal})
+	var buf bytes.Buffer
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		_ = encoder.Encode(&buf, p)
+	}
+}
+
+func benchmarkProblem() *ProblemDetail {
+	return &ProblemDetail{
+		Status:   http.StatusServiceUnavailable,
+		Title:    "service-unavailable",
+		Detail:   "payment service is not available",
+		Type:     "https://errors.example.com/service-unavailable",
+		Instance: "/orders/42",
+	}
+}
+
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (d *discardResponseWriter) Header() http.Header {
+	return d.header
+}
+
+func (d *discardResponseWriter) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (d *discardResponseWriter) WriteHeader(int) {}
+
+// frame stack frame in the shape of pkg/errors Frame
+type frame uintptr
+
+// tracedError error with stack trace in the shape of pkg/errors errors
+type tracedError struct {
+	msg   string
+	stack []frame
+}
+
+func newTracedError(msg string) error {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	stack := make([]frame, len(pcs))
+	for i, pc := range pcs {
+		stack[i] = frame(pc)
+	}
+	return &tracedError{msg: msg, stack: stack}
+}
+
+func (e *tracedError) Error() string {
+	return e.msg
+}
+
+func (e *tracedError) StackTrace() []frame {
+	return e.stack
+}
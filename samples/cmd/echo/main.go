@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 	"github.com/meysamhadeli/problem-details"
+	_ "github.com/meysamhadeli/problem-details/problemecho"
 	"github.com/meysamhadeli/problem-details/samples/custom-errors"
 	custom_problems "github.com/meysamhadeli/problem-details/samples/custom-problems"
 	"github.com/pkg/errors"
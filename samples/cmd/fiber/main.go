@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v3"
 	"github.com/labstack/gommon/log"
 	"github.com/meysamhadeli/problem-details"
+	"github.com/meysamhadeli/problem-details/problemfiber"
 	"github.com/meysamhadeli/problem-details/samples/custom-errors"
 	custom_problems "github.com/meysamhadeli/problem-details/samples/custom-problems"
 	"github.com/pkg/errors"
@@ -79,7 +80,7 @@ func FiberErrorHandler(c fiber.Ctx) error {
 		})
 
 		// resolve problem details error
-		if _, err := problem.ResolveProblemDetails(problem.Response(c), problem.Request(c), err); err != nil {
+		if _, err := problem.ResolveProblemDetails(problemfiber.Response(c), problemfiber.Request(c), err); err != nil {
 			log.Error(err)
 		}
 	}
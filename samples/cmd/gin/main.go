@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/labstack/gommon/log"
 	"github.com/meysamhadeli/problem-details"
+	_ "github.com/meysamhadeli/problem-details/problemgin"
 	custom_errors "github.com/meysamhadeli/problem-details/samples/custom-errors"
 	custom_problems "github.com/meysamhadeli/problem-details/samples/custom-problems"
 	"github.com/pkg/errors"
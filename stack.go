@@ -0,0 +1,132 @@
+package problem
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// StackExtension extension member carrying structured stack frames of the error
+const StackExtension = "stack"
+
+// defaultStackDepth max frames of the stack when StackOptions has no depth
+const defaultStackDepth = 32
+
+// StackFrame frame of the stack of the error
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// StackOptions options of capturing structured stack frames of the errors
+type StackOptions struct {
+	// Depth max frames of the stack, 32 frames are captured when it is zero
+	Depth int
+	// TrimPrefixes module paths and source directories which are trimmed from function and file of the frames
+	TrimPrefixes []string
+	// Filter report whether the frame is kept in the stack, DefaultStackFilter is used when it is nil
+	Filter func(frame StackFrame) bool
+}
+
+// stackInternals function prefixes of the frames of runtime, net/http, the resolver and web frameworks
+var stackInternals = []string{
+	"runtime.",
+	"net/http.",
+	"testing.",
+	"github.com/meysamhadeli/problem-details.",
+	"github.com/gin-gonic/gin.",
+	"github.com/labstack/echo/",
+	"github.com/gofiber/fiber/",
+	"github.com/valyala/fasthttp.",
+}
+
+// DefaultStackFilter filter frames of runtime, net/http, the resolver and web frameworks internals
+func DefaultStackFilter(frame StackFrame) bool {
+	for _, prefix := range stackInternals {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// StackFrames capture structured stack frames of the error from the innermost stack tracer in its chain, e.g. pkg/errors,
+// or from the current goroutine when the error has no stack
+func StackFrames(err error, opts StackOptions) []StackFrame {
+	depth := opts.Depth
+	if depth <= 0 {
+		depth = defaultStackDepth
+	}
+	filter := opts.Filter
+	if filter == nil {
+		filter = DefaultStackFilter
+	}
+
+	pcs := errorStack(err)
+	if pcs == nil {
+		pcs = make([]uintptr, 2*depth)
+		pcs = pcs[:runtime.Callers(2, pcs)]
+	}
+
+	frames := make([]StackFrame, 0, depth)
+	callers := runtime.CallersFrames(pcs)
+	for len(frames) < depth {
+		frame, more := callers.Next()
+		if frame.Function != "" {
+			stackFrame := StackFrame{
+				Function: trimPrefixes(frame.Function, opts.TrimPrefixes),
+				File:     trimPrefixes(frame.File, opts.TrimPrefixes),
+				Line:     frame.Line,
+			}
+			if filter(StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line}) {
+				frames = append(frames, stackFrame)
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// errorStack program counters of the innermost stack tracer in the chain of the error
+func errorStack(err error) []uintptr {
+	var pcs []uintptr
+	for err != nil {
+		if stack := tracedStack(err); stack != nil {
+			pcs = stack
+		}
+		err = errors.Unwrap(err)
+	}
+	return pcs
+}
+
+// tracedStack program counters of the StackTrace method of the error which returns a slice of uintptr frames,
+// like errors of pkg/errors and PanicError, it is detected by reflection to not depend on pkg/errors
+func tracedStack(err error) []uintptr {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+	if out := method.Type().Out(0); out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	stack := method.Call(nil)[0]
+	pcs := make([]uintptr, stack.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(stack.Index(i).Uint())
+	}
+	return pcs
+}
+
+func trimPrefixes(s string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return strings.TrimLeft(strings.TrimPrefix(s, prefix), "/.")
+		}
+	}
+	return s
+}
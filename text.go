@@ -0,0 +1,115 @@
+package problem
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FormatOptions options of Format
+type FormatOptions struct {
+	// Color colorize the output with ANSI escape codes for terminals
+	Color bool
+}
+
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+	ansiCyan   = "\033[36m"
+)
+
+type textEncoder struct{}
+
+// TextEncoder render problem details error as text/plain for curl users and command-line clients
+func TextEncoder() Encoder {
+	return textEncoder{}
+}
+
+func (textEncoder) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+func (textEncoder) Encode(w io.Writer, p ProblemDetailErr) error {
+	_, err := io.WriteString(w, Format(p, FormatOptions{}))
+	return err
+}
+
+// Format pretty-print problem details error, e.g. decoded ProblemDetail of a response on command-line clients
+func Format(p ProblemDetailErr, opts FormatOptions) string {
+	var sb strings.Builder
+
+	heading := strings.TrimSpace(fmt.Sprintf("%s %s", statusText(p.GetStatus()), p.GetTitle()))
+	sb.WriteString(colorize(opts, headingColor(p.GetStatus()), heading))
+	sb.WriteString("\n")
+	if p.GetDetails() != "" {
+		sb.WriteString(p.GetDetails())
+		sb.WriteString("\n")
+	}
+
+	var lines []member
+	if p.GetType() != "" {
+		lines = append(lines, member{"type", p.GetType()})
+	}
+	if p.GetInstance() != "" {
+		lines = append(lines, member{"instance", p.GetInstance()})
+	}
+	members, _ := problemMembers(p)
+	for _, m := range members {
+		if !standardMembers[m.name] {
+			value, err := formatValue(m.value)
+			if err != nil {
+				value = fmt.Sprint(m.value)
+			}
+			lines = append(lines, member{m.name, value})
+		}
+	}
+
+	var width int
+	for _, line := range lines {
+		width = max(width, len(line.name)+1)
+	}
+	if len(lines) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, line := range lines {
+		sb.WriteString(colorize(opts, ansiCyan, fmt.Sprintf("%-*s", width, line.name+":")))
+		sb.WriteString(" ")
+		sb.WriteString(line.value.(string))
+		sb.WriteString("\n")
+	}
+
+	if p.GetStackTrace() != "" {
+		sb.WriteString("\n")
+		sb.WriteString(colorize(opts, ansiCyan, "stackTrace:"))
+		sb.WriteString("\n")
+		sb.WriteString(strings.TrimRight(p.GetStackTrace(), "\n"))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func statusText(status int) string {
+	if status == 0 {
+		return ""
+	}
+	return fmt.Sprint(status)
+}
+
+func headingColor(status int) string {
+	if status >= 500 {
+		return ansiBold + ansiRed
+	}
+	if status >= 400 {
+		return ansiBold + ansiYellow
+	}
+	return ansiBold
+}
+
+func colorize(opts FormatOptions, color string, text string) string {
+	if !opts.Color {
+		return text
+	}
+	return color + text + ansiReset
+}
@@ -0,0 +1,47 @@
+package problem
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFormat_Decoded_Problem(t *testing.T) {
+
+	var p ProblemDetail
+	_ = json.Unmarshal([]byte(`{"type":"https://example.com/probs/out-of-credit","title":"You do not have enough credit.","status":403,"detail":"Your current balance is 30, but that costs 50.","instance":"/account/12345/msgs/abc","balance":30,"accounts":["/account/12345","/account/67890"]}`), &p)
+
+	text := Format(&p, FormatOptions{})
+
+	assert.Equal(t, `403 You do not have enough credit.
+Your current balance is 30, but that costs 50.
+
+type:     https://example.com/probs/out-of-credit
+instance: /account/12345/msgs/abc
+accounts: ["/account/12345","/account/67890"]
+balance:  30
+`, text)
+}
+
+func TestFormat_Color(t *testing.T) {
+
+	p := &ProblemDetail{Status: http.StatusInternalServerError, Title: "Internal Server Error", StackTrace: "main.handler\n\tmain.go:10\n"}
+
+	text := Format(p, FormatOptions{Color: true})
+
+	assert.Equal(t, "\033[1m\033[31m500 Internal Server Error\033[0m\n\n\033[36mstackTrace:\033[0m\nmain.handler\n\tmain.go:10\n", text)
+}
+
+func TestNegotiate_Text(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+	req.Header.Set("Accept", "text/plain")
+	rec := httptest.NewRecorder()
+
+	p, _ := ResolveProblemDetails(rec, req, endpoint3())
+
+	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "500 Internal Server Error\n"+p.GetDetails()+"\n")
+}
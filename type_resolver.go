@@ -0,0 +1,51 @@
+package problem
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// AboutBlank type of problems without additional semantics beyond the status code, the default of RFC 9457
+const AboutBlank = "about:blank"
+
+// TypeResolver resolve type of problem details error which has no type by its status code
+type TypeResolver interface {
+	ResolveType(statusCode int) string
+}
+
+// TypeResolverFunc function adapter of TypeResolver
+type TypeResolverFunc func(statusCode int) string
+
+func (f TypeResolverFunc) ResolveType(statusCode int) string {
+	return f(statusCode)
+}
+
+// AboutBlankType resolve type of all problems to about:blank
+func AboutBlankType() TypeResolver {
+	return TypeResolverFunc(func(int) string {
+		return AboutBlank
+	})
+}
+
+// TypeURI resolve type of problems with base URI template, {status} placeholder of the template is replaced with
+// the status code, e.g. https://errors.example.com/{status}
+func TypeURI(template string) TypeResolver {
+	types := map[int]string{}
+	for statusCode := 100; statusCode < 600; statusCode++ {
+		if http.StatusText(statusCode) != "" {
+			types[statusCode] = typeURI(template, statusCode)
+		}
+	}
+
+	return TypeResolverFunc(func(statusCode int) string {
+		if typ, ok := types[statusCode]; ok {
+			return typ
+		}
+		return typeURI(template, statusCode)
+	})
+}
+
+func typeURI(template string, statusCode int) string {
+	return strings.ReplaceAll(template, "{status}", strconv.Itoa(statusCode))
+}
@@ -0,0 +1,144 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+// Unwrapper unwrap error envelope (framework or application specific error) to status code, public message and inner cause
+type Unwrapper interface {
+	// Unwrap returns ok false when the error doesn't belong to the envelope, status code 0 and empty message keep the defaults
+	Unwrap(w http.ResponseWriter, err error) (statusCode int, message string, cause error, ok bool)
+}
+
+// UnwrapperFunc adapter to use ordinary function as Unwrapper
+type UnwrapperFunc func(w http.ResponseWriter, err error) (statusCode int, message string, cause error, ok bool)
+
+var unwrappers []Unwrapper
+
+func (f UnwrapperFunc) Unwrap(w http.ResponseWriter, err error) (int, string, error, bool) {
+	return f(w, err)
+}
+
+// RegisterUnwrapper register error envelope unwrapper for resolve problem details error, unwrappers are tried on the
+// errors of the chain from the outermost one, at each error in registration order, and the cause of the matched envelope
+// is unwrapped again, so nested envelopes, e.g. gin.Error of validator errors, resolve the same in any registration order
+func RegisterUnwrapper(unwrapper Unwrapper) {
+	unwrappers = append(unwrappers, unwrapper)
+}
+
+// UnwrapAs create unwrapper for error envelope of type T found in the error chain
+func UnwrapAs[T error](unwrap func(err T) (statusCode int, message string, cause error)) Unwrapper {
+	return UnwrapperFunc(func(_ http.ResponseWriter, err error) (int, string, error, bool) {
+		var target T
+		if !errors.As(err, &target) {
+			return 0, "", err, false
+		}
+		statusCode, message, cause := unwrap(target)
+		return statusCode, message, cause, true
+	})
+}
+
+// maxUnwrapDepth limit of nested envelopes which are unwrapped
+const maxUnwrapDepth = 8
+
+// unwrapError unwrap the error and the causes of its envelopes with the unwrappers of the resolver and the registered
+// unwrappers, status code and message of outer envelopes win
+func unwrapError(w http.ResponseWriter, err error, local []Unwrapper) (int, string, error) {
+	var statusCode int
+	var message string
+	for depth := 0; depth < maxUnwrapDepth; depth++ {
+		code, msg, cause, level, ok := unwrapChain(w, err, local)
+		if !ok {
+			break
+		}
+		if statusCode == 0 {
+			statusCode = code
+		}
+		if message == "" {
+			message = msg
+		}
+		if cause == nil {
+			break
+		}
+		if shim, self := cause.(chainError); self {
+			cause = shim.err
+		}
+		err = cause
+		if sameError(cause, level) {
+			break
+		}
+	}
+	return statusCode, message, err
+}
+
+// unwrapChain unwrap the outermost envelope of the error chain, it returns the error of the chain which is the envelope
+func unwrapChain(w http.ResponseWriter, err error, local []Unwrapper) (int, string, error, error, bool) {
+	for _, level := range errorChain(err) {
+		var single error = chainError{level}
+		if !wraps(level) {
+			single = level
+		}
+		for _, registered := range [][]Unwrapper{local, unwrappers} {
+			for _, unwrapper := range registered {
+				if statusCode, message, cause, ok := unwrapper.Unwrap(w, single); ok {
+					return statusCode, message, cause, level, true
+				}
+			}
+		}
+	}
+	return 0, "", err, nil, false
+}
+
+func wraps(err error) bool {
+	switch err.(type) {
+	case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+		return true
+	}
+	return false
+}
+
+// sameError report whether the errors are the same error, errors of uncomparable types are never the same
+func sameError(a error, b error) bool {
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b
+}
+
+// errorChain errors of the chain of the error in the order of errors.As
+func errorChain(err error) []error {
+	var errs []error
+	for err != nil {
+		errs = append(errs, err)
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, inner := range x.Unwrap() {
+				errs = append(errs, errorChain(inner)...)
+			}
+			return errs
+		default:
+			return errs
+		}
+	}
+	return errs
+}
+
+// chainError single wrapping error of the chain, errors.As finds the error itself but not the errors it wraps, so
+// unwrappers match only the envelope at the level of the chain
+type chainError struct {
+	err error
+}
+
+func (c chainError) Error() string {
+	return c.err.Error()
+}
+
+func (c chainError) As(target interface{}) bool {
+	v := reflect.ValueOf(target).Elem()
+	if !reflect.TypeOf(c.err).AssignableTo(v.Type()) {
+		return false
+	}
+	v.Set(reflect.ValueOf(c.err))
+	return true
+}
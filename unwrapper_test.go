@@ -0,0 +1,116 @@
+package problem
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnwrapper_Public_Message(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint5", nil)
+	rec := httptest.NewRecorder()
+
+	resolver := NewResolver(WithUnwrappers(UnwrapperFunc(func(w http.ResponseWriter, err error) (int, string, error, bool) {
+		var appErr *applicationError
+		if !errors.As(err, &appErr) {
+			return 0, "", err, false
+		}
+		return appErr.Status, appErr.Message, appErr.Cause, true
+	})))
+
+	err := &applicationError{Status: http.StatusServiceUnavailable, Message: "payment service is not available", Cause: errors.New("dial tcp 10.0.0.1:5432: connection refused")}
+
+	p, resolveErr := resolver.ResolveProblemDetails(rec, req, err)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "payment service is not available", p.GetDetails())
+	assert.Equal(t, http.StatusText(http.StatusServiceUnavailable), p.GetTitle())
+	assert.Equal(t, err.Cause, resolveErr)
+}
+
+func TestUnwrapper_Not_Matched(t *testing.T) {
+
+	var err = errors.New("We have a unhandeled error in our endpoint")
+
+	statusCode, message, cause := unwrapError(httptest.NewRecorder(), err, nil)
+
+	assert.Equal(t, 0, statusCode)
+	assert.Equal(t, "", message)
+	assert.Equal(t, err, cause)
+}
+
+func TestUnwrapper_Nested_Envelopes(t *testing.T) {
+
+	registered := unwrappers
+	defer func() {
+		unwrappers = registered
+	}()
+
+	outer := UnwrapAs(func(err *envelopeError) (int, string, error) {
+		return err.Status, "", err.Err
+	})
+	inner := UnwrapAs(func(err *applicationError) (int, string, error) {
+		return err.Status, err.Message, err.Cause
+	})
+
+	cause := errors.New("dial tcp 10.0.0.1:5432: connection refused")
+	err := &envelopeError{Status: http.StatusBadGateway, Err: &applicationError{Status: http.StatusServiceUnavailable, Message: "payment service is not available", Cause: cause}}
+
+	for _, order := range [][]Unwrapper{{outer, inner}, {inner, outer}} {
+		unwrappers = order
+
+		statusCode, message, unwrapped := unwrapError(httptest.NewRecorder(), err, nil)
+
+		assert.Equal(t, http.StatusBadGateway, statusCode)
+		assert.Equal(t, "payment service is not available", message)
+		assert.Equal(t, cause, unwrapped)
+	}
+}
+
+func TestResolver_Unwrappers(t *testing.T) {
+
+	registered := unwrappers
+	defer func() {
+		unwrappers = registered
+	}()
+	RegisterUnwrapper(UnwrapAs(func(err *envelopeError) (int, string, error) {
+		return http.StatusGatewayTimeout, "", err.Err
+	}))
+
+	resolver := NewResolver(WithUnwrappers(UnwrapAs(func(err *envelopeError) (int, string, error) {
+		return http.StatusServiceUnavailable, "payment service is not available", err.Err
+	})))
+	err := &envelopeError{Err: errors.New("dial tcp 10.0.0.1:5432: connection refused")}
+
+	p := resolver.ToProblemDetails(nil, err)
+
+	assert.Equal(t, http.StatusServiceUnavailable, p.GetStatus())
+	assert.Equal(t, "payment service is not available", p.GetDetails())
+	assert.Equal(t, http.StatusGatewayTimeout, ToProblemDetails(nil, err).GetStatus())
+}
+
+type envelopeError struct {
+	Status int
+	Err    error
+}
+
+func (e *envelopeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *envelopeError) Unwrap() error {
+	return e.Err
+}
+
+type applicationError struct {
+	Status  int
+	Message string
+	Cause   error
+}
+
+func (a *applicationError) Error() string {
+	return a.Message + ": " + a.Cause.Error()
+}
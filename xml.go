@@ -0,0 +1,111 @@
+package problem
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"unicode"
+)
+
+// XMLNamespace namespace of application/problem+xml problem details
+const XMLNamespace = "urn:ietf:rfc:7807"
+
+type xmlEncoder struct{}
+
+// XMLEncoder serialize problem details error with application/problem+xml media type base on RFC 9457 Appendix B,
+// extension arrays are serialized as <i> elements and extension objects as nested elements, members whose names aren't
+// XML names are serialized as <member> elements with the name in name attribute
+func XMLEncoder() Encoder {
+	return xmlEncoder{}
+}
+
+func (xmlEncoder) ContentType() string {
+	return "application/problem+xml"
+}
+
+func (xmlEncoder) Encode(w io.Writer, p ProblemDetailErr) error {
+	members, err := problemMembers(p)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	root := xml.StartElement{Name: xml.Name{Space: XMLNamespace, Local: "problem"}}
+	if err = encoder.EncodeToken(root); err != nil {
+		return err
+	}
+	for _, m := range members {
+		if err = encodeXMLElement(encoder, m.name, m.value); err != nil {
+			return err
+		}
+	}
+	if err = encoder.EncodeToken(root.End()); err != nil {
+		return err
+	}
+	return encoder.Flush()
+}
+
+// xmlMemberElement element of the members whose names aren't XML names, the name is kept in its name attribute
+const xmlMemberElement = "member"
+
+func encodeXMLElement(encoder *xml.Encoder, name string, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if !isXMLName(name) {
+		start = xml.StartElement{Name: xml.Name{Local: xmlMemberElement}, Attr: []xml.Attr{{Name: xml.Name{Local: "name"}, Value: name}}}
+	}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case nil:
+	case map[string]interface{}:
+		var names []string
+		for k := range v {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			if err := encodeXMLElement(encoder, k, v[k]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := encodeXMLElement(encoder, "i", item); err != nil {
+				return err
+			}
+		}
+	case json.Number:
+		if err := encoder.EncodeToken(xml.CharData(v.String())); err != nil {
+			return err
+		}
+	default:
+		if err := encoder.EncodeToken(xml.CharData(fmt.Sprint(v))); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+func isXMLName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if unicode.IsLetter(c) || c == '_' {
+			continue
+		}
+		if i > 0 && (unicode.IsDigit(c) || c == '-' || c == '.') {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package problem
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestXMLEncoder_Extensions(t *testing.T) {
+
+	p := &ProblemDetail{
+		Type:     "https://example.com/probs/out-of-credit",
+		Title:    "You do not have enough credit.",
+		Status:   http.StatusForbidden,
+		Detail:   "Your current balance is 30, but that costs 50.",
+		Instance: "/account/12345/msgs/abc",
+	}
+	p.SetExtension("balance", 30)
+	p.SetExtension("accounts", []string{"/account/12345", "/account/67890"})
+	p.SetExtension("limits", map[string]interface{}{"daily": 100, "per <minute>": 10})
+	p.SetExtension("invalid name", "kept")
+	p.SetExtension("", "empty")
+
+	var buf bytes.Buffer
+	err := XMLEncoder().Encode(&buf, p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<problem xmlns="urn:ietf:rfc:7807"><type>https://example.com/probs/out-of-credit</type><title>You do not have enough credit.</title><status>403</status><detail>Your current balance is 30, but that costs 50.</detail><instance>/account/12345/msgs/abc</instance><member name="">empty</member><accounts><i>/account/12345</i><i>/account/67890</i></accounts><balance>30</balance><member name="invalid name">kept</member><limits><daily>100</daily><member name="per &lt;minute&gt;">10</member></limits></problem>`, buf.String())
+}
+
+func TestXMLEncoder_Custom_Problem(t *testing.T) {
+
+	p := &CustomProblemDetailTest{
+		ProblemDetailErr: &ProblemDetail{
+			Status: http.StatusConflict,
+			Title:  "conflict",
+			Detail: "<order> & co",
+		},
+		Description: "some description...",
+	}
+
+	var buf bytes.Buffer
+	err := XMLEncoder().Encode(&buf, p)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `<title>conflict</title><status>409</status><detail>&lt;order&gt; &amp; co</detail><description>some description...</description></problem>`)
+}
+
+func TestResolver_XMLEncoder(t *testing.T) {
+
+	req := httptest.NewRequest(http.MethodGet, "http://endpoint3", nil)
+	rec := httptest.NewRecorder()
+
+	resolver := NewResolver(WithEncoder(XMLEncoder()))
+
+	p, _ := resolver.ResolveProblemDetails(rec, req, endpoint3())
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/problem+xml", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "<detail>"+p.GetDetails()+"</detail>")
+}